@@ -0,0 +1,51 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+// DuplicateKey is the policy of duplicate object key for loading
+type DuplicateKey int
+
+// DuplicateKey values, if not set the last value is kept as encoding/json
+const (
+	// DuplicateLast keeps the last value
+	DuplicateLast DuplicateKey = iota + 1
+	// DuplicateFirst keeps the first value
+	DuplicateFirst
+	// DuplicateError returns *SyntaxError of ErrDuplicateKey with the duplicate path
+	DuplicateError
+	// DuplicateArray collects all the values into an array
+	DuplicateArray
+)
+
+// SetDuplicateKey set the duplicate key policy for loading, it is not set by default
+// when it is set, the paths of duplicate keys are recorded and could be got by Duplicates
+//   json := New()
+//   json.SetDuplicateKey(DuplicateArray)
+//   json.Loads(`{"a": 1, "a": 2}`) // {"a": [1, 2]}
+//   json.Duplicates()              // [a]
+func (j *Json) SetDuplicateKey(policy DuplicateKey) {
+	j.duplicate = policy
+}
+
+// Duplicates returns the dot(.) separated paths of duplicate keys found by the last loading,
+// a path appears once for every duplicate, only recorded if the duplicate key policy is set
+func (j *Json) Duplicates() []string {
+	return j.duplicates
+}
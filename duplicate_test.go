@@ -0,0 +1,94 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Duplicate_Key(t *testing.T) {
+	text := `{"a": 1, "b": {"c": [1], "c": 2, "c": {"d": 3}}, "a": 4, "e": [{"f": 5, "f": 6}]}`
+
+	tests := []struct {
+		policy DuplicateKey
+		expect string
+	}{
+		{0, `{"a":4,"b":{"c":{"d":3}},"e":[{"f":6}]}`},
+		{DuplicateLast, `{"a":4,"b":{"c":{"d":3}},"e":[{"f":6}]}`},
+		{DuplicateFirst, `{"a":1,"b":{"c":[1]},"e":[{"f":5}]}`},
+		{DuplicateArray, `{"a":[1,4],"b":{"c":[[1],2,{"d":3}]},"e":[{"f":[5,6]}]}`},
+	}
+
+	for _, v := range tests {
+		j := New()
+		j.SetDuplicateKey(v.policy)
+		err := j.Loads(text)
+		assert.Nil(t, err)
+		s, err := j.Dumps()
+		assert.Nil(t, err)
+		assert.Equal(t, s, v.expect)
+		if v.policy == 0 {
+			assert.Len(t, j.Duplicates(), 0)
+		} else {
+			assert.Equal(t, j.Duplicates(), []string{"b.c", "b.c", "a", "e.0.f"})
+		}
+	}
+
+	j := New()
+	j.SetDuplicateKey(DuplicateError)
+	err := j.Loads("{\n  \"a\": {\"b\": 1, \"b\": 2}\n}")
+	assert.NotNil(t, err)
+	assert.Equal(t, err.Error(), "simplejson: 2:17: duplicate key a.b")
+	assert.Equal(t, err.(*SyntaxError).Unwrap(), ErrDuplicateKey)
+
+	err = j.Loads(`{"a": 1, "b": 2}`)
+	assert.Nil(t, err)
+	assert.Len(t, j.Duplicates(), 0)
+
+	j = New()
+	j.SetOrdered(true)
+	j.SetDuplicateKey(DuplicateArray)
+	err = j.LoadsJSON5(`{b: 1, a: 2, b: 3}`)
+	assert.Nil(t, err)
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"b":[1,3],"a":2}`)
+	assert.Equal(t, j.Duplicates(), []string{"b"})
+
+	// Objects at the same path are collected separately
+	collected := map[string]string{
+		`{"a": {"x":1,"x":2}, "a": {"x":3,"x":4}}`:                   `{"a":[{"x":[1,2]},{"x":[3,4]}]}`,
+		`{"a": {"x":1,"x":2}, "a": {"x":3,"x":4}, "a": 5}`:           `{"a":[{"x":[1,2]},{"x":[3,4]},5]}`,
+		`{"a.x": 1, "a": {"x": 2}, "a.x": 3, "a": {"x": 4, "x": 5}}`: `{"a":[{"x":2},{"x":[4,5]}],"a.x":[1,3]}`,
+		`[{"a": 1, "a": 2}, {"a": 3, "a": 4, "a": 5}]`:               `[{"a":[1,2]},{"a":[3,4,5]}]`,
+	}
+
+	for k, v := range collected {
+		j = New()
+		j.SetDuplicateKey(DuplicateArray)
+		err = j.Loads(k)
+		assert.Nil(t, err, k)
+		s, err = j.Dumps()
+		assert.Nil(t, err, k)
+		assert.Equal(t, s, v, k)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// encoder is the json encoder for the features beyond encoding/json
+type encoder struct {
+	buf        bytes.Buffer
+	escapeHtml bool
+	// json5 encodes the keys of identifier unquoted, and Infinity and NaN as it is if nonFinite is not set
+	json5 bool
+	// nonFinite is the policy of Infinity and NaN, 0 if not set
+	nonFinite NonFinite
+	// indent is the indentation of each level, compact if empty
+	indent string
+	// base is the path of the value encoded
+	base string
+	// keys is the keys of current value from base
+	keys []string
+}
+
+var (
+	// marshalerType is the type of json.Marshaler
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	// textMarshalerType is the type of encoding.TextMarshaler
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// encode encodes the value, maps, slices and floats are encoded by encoder,
+// other values are encoded by encoding/json
+func (e *encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	if o, ok := v.Interface().(*Object); ok && o != nil {
+		values := map[string]reflect.Value{}
+		for k, vv := range o.values {
+			values[k] = reflect.ValueOf(vv)
+		}
+		return e.object(o.Keys(), values)
+	}
+
+	if v.Type().Implements(marshalerType) || v.Type().Implements(textMarshalerType) {
+		return e.marshal(v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return e.float(v, f)
+		}
+	case reflect.Map:
+		if !v.IsNil() && v.Type().Key().Kind() == reflect.String {
+			keys := make([]string, 0, v.Len())
+			values := map[string]reflect.Value{}
+			for _, k := range v.MapKeys() {
+				keys = append(keys, k.String())
+				values[k.String()] = v.MapIndex(k)
+			}
+			sort.Strings(keys)
+			return e.object(keys, values)
+		}
+	case reflect.Slice:
+		if !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8 {
+			return e.array(v)
+		}
+	case reflect.Array:
+		return e.array(v)
+	}
+
+	return e.marshal(v.Interface())
+}
+
+// object encodes the values of object in the order of keys
+func (e *encoder) object(keys []string, values map[string]reflect.Value) error {
+	e.buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.newline(len(e.keys) + 1)
+		if e.json5 && isIdentifierName(k) {
+			e.buf.WriteString(k)
+		} else if err := e.marshal(k); err != nil {
+			return err
+		}
+		e.buf.WriteByte(':')
+		if e.indent != "" {
+			e.buf.WriteByte(' ')
+		}
+		e.keys = append(e.keys, k)
+		err := e.encode(values[k])
+		e.keys = e.keys[:len(e.keys)-1]
+		if err != nil {
+			return err
+		}
+	}
+	if len(keys) > 0 {
+		e.newline(len(e.keys))
+	}
+	e.buf.WriteByte('}')
+
+	return nil
+}
+
+// array encodes the slice or array
+func (e *encoder) array(v reflect.Value) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.newline(len(e.keys) + 1)
+		e.keys = append(e.keys, strconv.Itoa(i))
+		err := e.encode(v.Index(i))
+		e.keys = e.keys[:len(e.keys)-1]
+		if err != nil {
+			return err
+		}
+	}
+	if v.Len() > 0 {
+		e.newline(len(e.keys))
+	}
+	e.buf.WriteByte(']')
+
+	return nil
+}
+
+// newline writes a newline and the indentation of depth if indent is set
+func (e *encoder) newline(depth int) {
+	if e.indent == "" {
+		return
+	}
+
+	e.buf.WriteByte('\n')
+	for i := 0; i < depth; i++ {
+		e.buf.WriteString(e.indent)
+	}
+}
+
+// float encodes the float of Infinity or NaN by the nonFinite policy
+func (e *encoder) float(v reflect.Value, f float64) error {
+	policy := e.nonFinite
+	if policy == 0 && e.json5 {
+		policy = NonFiniteLiteral
+	}
+
+	text := nonFiniteText(f)
+
+	switch policy {
+	case NonFiniteError:
+		return &PathError{Op: "Dumps", Path: joinPath(e.base, strings.Join(e.keys, ".")), Err: ErrNonFinite}
+	case NonFiniteNull:
+		e.buf.WriteString("null")
+	case NonFiniteString:
+		e.buf.WriteString(`"` + text + `"`)
+	case NonFiniteLiteral:
+		e.buf.WriteString(text)
+	default:
+		return &json.UnsupportedValueError{Value: v, Str: strconv.FormatFloat(f, 'g', -1, 64)}
+	}
+
+	return nil
+}
+
+// marshal encodes the value by encoding/json
+func (e *encoder) marshal(v interface{}) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(e.escapeHtml)
+	err := enc.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	data := bytes.TrimRight(buf.Bytes(), "\n")
+	if e.indent == "" {
+		e.buf.Write(data)
+		return nil
+	}
+
+	return json.Indent(&e.buf, data, strings.Repeat(e.indent, len(e.keys)), e.indent)
+}
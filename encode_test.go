@@ -0,0 +1,73 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Encoder(t *testing.T) {
+	type data struct {
+		Name string `json:"name"`
+	}
+
+	s := "<a>"
+	tests := []struct {
+		in  interface{}
+		out string
+	}{
+		{nil, `null`},
+		{(*int)(nil), `null`},
+		{&s, `"<a>"`},
+		{[]byte("a"), `"YQ=="`},
+		{[]int(nil), `null`},
+		{[2]int{1, 2}, `[1,2]`},
+		{map[string]int{"b": 2, "a": 1}, `{"a":1,"b":2}`},
+		{map[int]string{1: "a"}, `{"1":"a"}`},
+		{data{"a"}, `{"name":"a"}`},
+		{json.Number("1.0"), `1.0`},
+		{time.Unix(0, 0).UTC(), `"1970-01-01T00:00:00Z"`},
+		{[]interface{}{1.5, float32(2), "b", true}, `[1.5,2,"b",true]`},
+	}
+
+	for _, v := range tests {
+		e := &encoder{}
+		err := e.encode(reflect.ValueOf(v.in))
+		assert.Nil(t, err, v.in)
+		assert.Equal(t, e.buf.String(), v.out, v.in)
+	}
+
+	e := &encoder{escapeHtml: true}
+	err := e.encode(reflect.ValueOf(map[string]string{"<": ">"}))
+	assert.Nil(t, err)
+	assert.Equal(t, e.buf.String(), `{"\u003c":"\u003e"}`)
+
+	for _, v := range []interface{}{math.NaN(), []float64{math.Inf(1)}, map[string]interface{}{"a": math.Inf(-1)}, make(chan int)} {
+		e := &encoder{}
+		err := e.encode(reflect.ValueOf(v))
+		assert.NotNil(t, err)
+	}
+}
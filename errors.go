@@ -0,0 +1,280 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrTooManyArguments is the error of too many arguments
+	ErrTooManyArguments = errors.New("too many arguments")
+	// ErrInvalidArgument is the error of invalid argument type
+	ErrInvalidArgument = errors.New("invalid argument type")
+	// ErrDuplicateKey is the error of duplicate object key
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrLimitExceeded is the error of data exceeding the limits
+	ErrLimitExceeded = errors.New("limit exceeded")
+	// ErrNonFinite is the error of float Infinity or NaN by dumping
+	ErrNonFinite = errors.New("unsupported non-finite float")
+)
+
+// PathError records an error and the operation and path that caused it
+// it is the panic value of MustXXX, and could be recovered by Try
+type PathError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+// Error returns the string of error
+func (e *PathError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "(root)"
+	}
+
+	return "simplejson: " + e.Op + " " + path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// LineError records an error and the line number that caused it
+type LineError struct {
+	Line int
+	Err  error
+}
+
+// Error returns the string of error
+// *SyntaxError is returned as it is, which has the line and column already
+func (e *LineError) Error() string {
+	if s, ok := e.Err.(*SyntaxError); ok {
+		return s.Error()
+	}
+
+	return "simplejson: line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// SyntaxError is the error of invalid json, with the position of error
+//   simplejson: config.json:3:12: invalid character 'x' looking for beginning of value
+//   Snippet:
+//       "port": x,
+//               ^
+type SyntaxError struct {
+	// File is the file name if loaded by Load
+	File string
+	// Line is the line number, starts from 1
+	Line int
+	// Column is the column number in characters, starts from 1
+	Column int
+	// Offset is the byte offset, starts from 0
+	Offset int64
+	// Msg is the description of error
+	Msg string
+	// Snippet is the line of error, and a caret pointing at the column in the next line
+	Snippet string
+	// Err is the underlying error if any, such as ErrDuplicateKey and ErrLimitExceeded
+	Err error
+}
+
+// Error returns the string of error
+func (e *SyntaxError) Error() string {
+	position := strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column)
+	if e.File != "" {
+		position = e.File + ":" + position
+	}
+
+	return "simplejson: " + position + ": " + e.Msg
+}
+
+// Unwrap returns the underlying error
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// newSyntaxError returns a *SyntaxError of msg at offset of data
+func newSyntaxError(data []byte, offset int, msg string) *SyntaxError {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := bytes.IndexByte(data[offset:], '\n')
+	if end < 0 {
+		end = len(data)
+	} else {
+		end += offset
+	}
+
+	before := []rune(string(data[start:offset]))
+	after := []rune(strings.TrimRight(string(data[offset:end]), "\r"))
+
+	e := &SyntaxError{
+		Line:   bytes.Count(data[:offset], []byte{'\n'}) + 1,
+		Column: len(before) + 1,
+		Offset: int64(offset),
+		Msg:    msg,
+	}
+
+	// keep the snippet short for long line
+	prefix, suffix := "", ""
+	if len(before) > 40 {
+		before = before[len(before)-40:]
+		prefix = "..."
+	}
+	if len(after) > 40 {
+		after = after[:40]
+		suffix = "..."
+	}
+
+	caret := []rune(prefix)
+	for _, r := range before {
+		caret = append(caret, r)
+	}
+	for i, r := range caret {
+		if r != '\t' {
+			caret[i] = ' '
+		}
+	}
+
+	e.Snippet = prefix + string(before) + string(after) + suffix + "\n" + string(caret) + "^"
+
+	return e
+}
+
+// tailSize is the bytes kept by tail before the last write
+const tailSize = 4096
+
+// tail keeps the tail of data written, for the *SyntaxError of reader without keeping the whole data
+type tail struct {
+	// data is the data kept
+	data []byte
+	// offset is the offset of data[0]
+	offset int
+	// lines is the count of newlines before data[0]
+	lines int
+	// column is the count of characters from the line start to data[0]
+	column int
+	// max is the bytes kept before the last write, 0 is unlimited
+	max int
+}
+
+// Write appends p to data, drops the data more than max before p
+func (t *tail) Write(p []byte) (int, error) {
+	t.data = append(t.data, p...)
+
+	drop := len(t.data) - len(p) - t.max
+	if t.max > 0 && drop > 0 {
+		for drop < len(t.data) && !utf8.RuneStart(t.data[drop]) {
+			drop++
+		}
+		for _, c := range t.data[:drop] {
+			if c == '\n' {
+				t.lines++
+				t.column = 0
+			} else if utf8.RuneStart(c) {
+				t.column++
+			}
+		}
+		t.offset += drop
+		t.data = append(t.data[:0], t.data[drop:]...)
+	}
+
+	return len(p), nil
+}
+
+// end returns the offset after the data written
+func (t *tail) end() int {
+	return t.offset + len(t.data)
+}
+
+// syntaxError returns a *SyntaxError of msg at offset of the whole data written
+func (t *tail) syntaxError(offset int, msg string) *SyntaxError {
+	e := newSyntaxError(t.data, offset-t.offset, msg)
+	if e.Line == 1 {
+		e.Column += t.column
+	}
+	e.Line += t.lines
+	e.Offset += int64(t.offset)
+
+	return e
+}
+
+// decodeError returns the *SyntaxError of the error returned by encoding/json decoding data of t
+// other errors are returned as it is
+func decodeError(t *tail, err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return t.syntaxError(int(e.Offset)-1, e.Error())
+	default:
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return t.syntaxError(t.end(), "unexpected end of JSON input")
+		}
+		return err
+	}
+}
+
+// Try calls fn and returns the *PathError panicked by MustXXX, other panics are not recovered
+//   err := Try(func() {
+//       port = json.Get("db.port").MustInt()
+//       host = json.Get("db.host").MustString()
+//   })
+func Try(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*PathError)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+
+	fn()
+
+	return
+}
+
+// pathError returns a *PathError of op and err at the path of json object
+func (j *Json) pathError(op string, err error) *PathError {
+	return &PathError{
+		Op:   op,
+		Path: j.path,
+		Err:  err,
+	}
+}
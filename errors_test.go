@@ -0,0 +1,217 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Path(t *testing.T) {
+	jsonData, err := Loads(textResult)
+	assert.Nil(t, err)
+
+	assert.Equal(t, jsonData.Path(), "")
+	assert.Equal(t, jsonData.Get("status").Path(), "status")
+	assert.Equal(t, jsonData.Get(" status . code ").Path(), "status.code")
+	assert.Equal(t, jsonData.Get("status").Get("code").Path(), "status.code")
+	assert.Equal(t, jsonData.Get("result.intlist.3").Path(), "result.intlist.3")
+	assert.Equal(t, jsonData.Get("result.intlist").Index(3).Path(), "result.intlist.3")
+
+	// Path of not exists key
+	assert.Equal(t, jsonData.Get("status.not-exists.code").Path(), "status.not-exists.code")
+	assert.Equal(t, jsonData.Get("result.intlist.x").Path(), "result.intlist.x")
+	assert.Equal(t, jsonData.Get("result.intlist.666.x").Path(), "result.intlist.666.x")
+	assert.Equal(t, jsonData.Get("result.online.x").Path(), "result.online.x")
+	assert.Equal(t, jsonData.Get("status").Index(1).Path(), "status.1")
+}
+
+func Test_Must_Path_Error(t *testing.T) {
+	jsonData, err := Loads(textResult)
+	assert.Nil(t, err)
+
+	// Panic with *PathError
+	err = Try(func() {
+		jsonData.Get("status.message").MustInt()
+	})
+	assert.NotNil(t, err)
+	e, ok := err.(*PathError)
+	assert.True(t, ok)
+	assert.Equal(t, e.Op, "MustInt")
+	assert.Equal(t, e.Path, "status.message")
+	assert.Equal(t, e.Unwrap(), e.Err)
+	assert.Equal(t, err.Error(), "simplejson: MustInt status.message: invalid value type")
+
+	// Root path
+	err = Try(func() {
+		jsonData.MustArray()
+	})
+	assert.Equal(t, err.Error(), "simplejson: MustArray (root): assert to array failed")
+
+	// Too many arguments
+	err = Try(func() {
+		jsonData.Get("status.code").MustString("a", "b")
+	})
+	assert.Equal(t, err.(*PathError).Err, ErrTooManyArguments)
+
+	// Invalid argument
+	err = Try(func() {
+		jsonData.Get("status.code").MustTime(1)
+	})
+	assert.Equal(t, err.(*PathError).Err, ErrInvalidArgument)
+	assert.Equal(t, err.(*PathError).Path, "status.code")
+
+	// First panic is returned
+	var code int
+	var message string
+	err = Try(func() {
+		code = jsonData.Get("status.code").MustInt()
+		message = jsonData.Get("status.message").MustString()
+		_ = jsonData.Get("status.not-exists").MustTime()
+		_ = jsonData.Get("status.code").MustBool()
+	})
+	assert.Equal(t, code, 1)
+	assert.Equal(t, message, "success")
+	assert.Equal(t, err.(*PathError).Op, "MustTime")
+	assert.Equal(t, err.(*PathError).Path, "status.not-exists")
+
+	// No panic
+	err = Try(func() {
+		_ = jsonData.Get("status.code").MustInt()
+		_ = jsonData.Get("time").MustTime(time.Now())
+	})
+	assert.Nil(t, err)
+
+	// Other panic is not recovered
+	assert.Panic(t, func() {
+		_ = Try(func() {
+			panic("other")
+		})
+	})
+}
+
+func Test_Syntax_Error(t *testing.T) {
+	text := "{\n    \"name\": \"中文\", \"port\": x,\n    \"host\": \"localhost\"\n}"
+
+	// Loads with invalid character
+	_, err := Loads(text)
+	assert.NotNil(t, err)
+	e, ok := err.(*SyntaxError)
+	assert.True(t, ok)
+	assert.Equal(t, e.Line, 2)
+	assert.Equal(t, e.Column, 27)
+	assert.Equal(t, e.Offset, int64(32))
+	assert.Equal(t, e.Msg, "invalid character 'x' looking for beginning of value")
+	assert.Equal(t, e.Snippet, "    \"name\": \"中文\", \"port\": x,\n                          ^")
+	assert.Equal(t, e.Error(), "simplejson: 2:27: invalid character 'x' looking for beginning of value")
+
+	// LoadBytes and LoadReader
+	_, err = LoadBytes([]byte(text))
+	assert.Equal(t, err, e)
+	_, err = LoadReader(strings.NewReader(text))
+	assert.Equal(t, err, e)
+
+	// Load with file name
+	defer os.Remove(textFile)
+	err = ioutil.WriteFile(textFile, []byte(text), 0644)
+	assert.Nil(t, err)
+	_, err = Load(textFile)
+	assert.NotNil(t, err)
+	assert.Equal(t, err.(*SyntaxError).File, textFile)
+	assert.Equal(t, err.Error(), "simplejson: "+textFile+":2:27: invalid character 'x' looking for beginning of value")
+
+	// Unexpected end
+	_, err = Loads("{\n\t\"a\": [1,")
+	assert.Equal(t, err.(*SyntaxError).Line, 2)
+	assert.Equal(t, err.(*SyntaxError).Column, 10)
+	assert.Equal(t, err.(*SyntaxError).Msg, "unexpected end of JSON input")
+	assert.Equal(t, err.(*SyntaxError).Snippet, "\t\"a\": [1,\n\t        ^")
+
+	_, err = Loads("")
+	assert.Equal(t, err.(*SyntaxError).Line, 1)
+	assert.Equal(t, err.(*SyntaxError).Column, 1)
+	assert.Equal(t, err.(*SyntaxError).Snippet, "\n^")
+
+	// Trailing data in strict mode
+	j := New()
+	j.SetStrict(true)
+	err = j.Loads("{\"a\": 1}\r\n  {\"b\": 2}")
+	assert.Equal(t, err.(*SyntaxError).Line, 2)
+	assert.Equal(t, err.(*SyntaxError).Column, 3)
+	assert.Equal(t, err.(*SyntaxError).Msg, "invalid character '{' after top-level value")
+	err = j.LoadReader(strings.NewReader("[1] 2"))
+	assert.Equal(t, err.(*SyntaxError).Offset, int64(4))
+	assert.Equal(t, err.(*SyntaxError).Msg, "invalid character '2' after top-level value")
+
+	// The same message as the parser
+	j.SetJSONC(true)
+	err = j.Loads("{\"a\": 1}\r\n  {\"b\": 2}")
+	assert.Equal(t, err.(*SyntaxError).Msg, "invalid character '{' after top-level value")
+	j.SetJSONC(false)
+
+	// Long line is cut in snippet
+	_, err = Loads(`{"a": "` + strings.Repeat("a", 50) + `" x "` + strings.Repeat("b", 50) + `"}`)
+	assert.Equal(t, err.(*SyntaxError).Column, 60)
+	assert.Equal(t, err.(*SyntaxError).Snippet, "..."+strings.Repeat("a", 38)+`" x "`+strings.Repeat("b", 37)+"...\n"+strings.Repeat(" ", 43)+"^")
+
+	// Errors of LoadsPartial and Repair
+	_, _, err = LoadsPartial("[1,\n 2 x")
+	assert.Equal(t, err.(*SyntaxError).Line, 2)
+	assert.Equal(t, err.(*SyntaxError).Column, 4)
+	_, _, err = Repair("[1,\n @]")
+	assert.Equal(t, err.(*SyntaxError).Column, 2)
+
+	// LoadReader keeps only the tail of data for error
+	long := "[\n" + strings.Repeat(`"中文", 1,`+"\n", 2000) + strings.Repeat(`"a", `, 2000) + "x]"
+	for _, v := range []string{long, long[:len(long)-2], long + " 1"} {
+		j = New()
+		j.SetStrict(true)
+		expect := j.Loads(v)
+		assert.NotNil(t, expect)
+		err = j.LoadReader(strings.NewReader(v))
+		assert.Equal(t, err, expect)
+		// the snippet ends at where reading stopped
+		err = j.LoadReader(iotest.OneByteReader(strings.NewReader(v)))
+		e, x := err.(*SyntaxError), expect.(*SyntaxError)
+		assert.Equal(t, []interface{}{e.Line, e.Column, e.Offset, e.Msg}, []interface{}{x.Line, x.Column, x.Offset, x.Msg})
+		assert.True(t, strings.HasPrefix(x.Snippet, strings.Split(e.Snippet, "\n")[0]))
+	}
+
+	tl := &tail{max: 10}
+	for i := 0; i < 100; i++ {
+		_, _ = tl.Write([]byte("ab\n中"))
+	}
+	assert.True(t, len(tl.data) <= 10+6)
+	assert.Equal(t, tl.end(), 600)
+	assert.Equal(t, tl.syntaxError(597, "x").Line, 101)
+	assert.Equal(t, tl.syntaxError(597, "x").Column, 1)
+	assert.Equal(t, tl.syntaxError(595, "x").Line, 100)
+	assert.Equal(t, tl.syntaxError(595, "x").Column, 3)
+
+	// Other error is returned as it is
+	_, err = LoadReader(errorReader{})
+	assert.Equal(t, err.Error(), "read failed")
+}
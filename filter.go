@@ -0,0 +1,197 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Filter copies json from io.Reader to io.Writer token by token, without loading the whole json,
+// and rewrites the values at paths, path is dot(.) separated key as Get, "*" matches any key or index
+// if a path matches more than one rule, the first added rule is used,
+// and rules of the children of a matched value are not applied
+//   f := NewFilter()
+//   f.Mask("user.password", "******")
+//   f.Drop("items.*.secret")
+//   f.Replace("items.*.email", func(path string, value *Json) (interface{}, error) {
+//       return strings.ToLower(value.MustString("")), nil
+//   })
+//   err := f.Run(r, w)
+type Filter struct {
+	rules      []*filterRule
+	escapeHtml bool
+	pretty     bool
+}
+
+// filterRule is a rule of Filter
+type filterRule struct {
+	keys    []string
+	drop    bool
+	mask    interface{}
+	replace func(path string, value *Json) (interface{}, error)
+}
+
+// NewFilter returns a new Filter
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// SetHtmlEscape set html escape for escaping of <, >, and & in JSON strings
+func (f *Filter) SetHtmlEscape(escape bool) {
+	f.escapeHtml = escape
+}
+
+// SetPretty set pretty output with identation as PrettyDumps
+func (f *Filter) SetPretty(pretty bool) {
+	f.pretty = pretty
+}
+
+// Mask replaces the values at path with mask, the value is not read into memory
+func (f *Filter) Mask(path string, mask interface{}) {
+	f.rules = append(f.rules, &filterRule{keys: splitPaths([]string{path})[0], mask: mask})
+}
+
+// Drop removes the values at path, the key of object or item of array is removed too,
+// the top level value is replaced with null
+func (f *Filter) Drop(path string) {
+	f.rules = append(f.rules, &filterRule{keys: splitPaths([]string{path})[0], drop: true})
+}
+
+// Replace replaces the values at path with the returned value of fn, returned value can be *Json
+// or any data could be used by New, return error to stop the filtering
+func (f *Filter) Replace(path string, fn func(path string, value *Json) (interface{}, error)) {
+	f.rules = append(f.rules, &filterRule{keys: splitPaths([]string{path})[0], replace: fn})
+}
+
+// Run reads json from r, writes the filtered json to w
+func (f *Filter) Run(r io.Reader, w io.Writer) error {
+	s := newStreamer(r)
+
+	sw := NewStreamWriter(w)
+	sw.SetHtmlEscape(f.escapeHtml)
+	sw.SetPretty(f.pretty)
+
+	err := f.copy(s, sw, []string{})
+	if err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+// copy copies the next json value at keys from s to w
+func (f *Filter) copy(s *streamer, w *StreamWriter, keys []string) error {
+	rule := f.match(keys)
+	if rule != nil {
+		switch {
+		case rule.drop:
+			err := s.skip()
+			if err != nil {
+				return err
+			}
+			return w.Value(nil)
+		case rule.replace != nil:
+			var data interface{}
+			err := s.dec.Decode(&data)
+			if err != nil {
+				return err
+			}
+			j := New(data)
+			j.path = strings.Join(keys, ".")
+			value, err := rule.replace(j.path, j)
+			if err != nil {
+				return err
+			}
+			return w.Value(value)
+		default:
+			err := s.skip()
+			if err != nil {
+				return err
+			}
+			return w.Value(rule.mask)
+		}
+	}
+
+	t, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		w.BeginObject()
+		for s.dec.More() {
+			k, err := s.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := k.(string)
+			ks := append(keys[:len(keys):len(keys)], key)
+			if r := f.match(ks); r != nil && r.drop {
+				err = s.skip()
+			} else {
+				w.Key(key)
+				err = f.copy(s, w, ks)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		_, err = s.dec.Token()
+		if err != nil {
+			return err
+		}
+		return w.End()
+	case json.Delim('['):
+		w.BeginArray()
+		for i := 0; s.dec.More(); i++ {
+			ks := append(keys[:len(keys):len(keys)], strconv.Itoa(i))
+			if r := f.match(ks); r != nil && r.drop {
+				err = s.skip()
+			} else {
+				err = f.copy(s, w, ks)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		_, err = s.dec.Token()
+		if err != nil {
+			return err
+		}
+		return w.End()
+	default:
+		return w.Value(t)
+	}
+}
+
+// match returns the first rule matching keys, nil if there is none
+func (f *Filter) match(keys []string) *filterRule {
+	for _, v := range f.rules {
+		if matchKeys(keys, v.keys) {
+			return v
+		}
+	}
+
+	return nil
+}
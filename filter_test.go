@@ -0,0 +1,119 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Filter(t *testing.T) {
+	text := `{"user": {"name": "kexian", "password": "123456", "token": {"a": [1, 2]}},
+		"items": [{"id": 1.50, "email": "A@B.COM", "secret": "x"}, {"id": 2, "secret": {"k": "v"}}, "<tag>"],
+		"drop": [1, 2, 3, 4], "big": 12345678901234567890}`
+
+	var buf bytes.Buffer
+
+	f := NewFilter()
+	f.Mask("user.password", "******")
+	f.Mask("user.token", nil)
+	f.Drop("items.*.secret")
+	f.Drop("drop.1")
+	f.Drop("drop.2")
+	f.Replace("items.*.email", func(path string, value *Json) (interface{}, error) {
+		assert.Equal(t, path, "items.0.email")
+		assert.Equal(t, value.Path(), path)
+		return strings.ToLower(value.MustString("")), nil
+	})
+	f.Replace("items.1.id", func(path string, value *Json) (interface{}, error) {
+		return value, nil
+	})
+	err := f.Run(strings.NewReader(text), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), `{"user":{"name":"kexian","password":"******","token":null},`+
+		`"items":[{"id":1.50,"email":"a@b.com"},{"id":2},"<tag>"],"drop":[1,4],"big":12345678901234567890}`)
+
+	// Pretty and html escape
+	buf.Reset()
+	f = NewFilter()
+	f.SetPretty(true)
+	f.SetHtmlEscape(true)
+	f.Replace("items.2", func(path string, value *Json) (interface{}, error) {
+		return New([]string{value.Index(0).MustString()}), nil
+	})
+	err = f.Run(strings.NewReader(`{"items": [{}, [], ["<tag>"]]}`), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "{\n    \"items\": [\n        {},\n        [],\n        [\n            \"\\u003ctag\\u003e\"\n        ]\n    ]\n}")
+
+	// Rules of children of a matched value are not applied
+	buf.Reset()
+	f = NewFilter()
+	f.Replace("items.*", func(path string, value *Json) (interface{}, error) {
+		return value, nil
+	})
+	f.Drop("items.*.secret")
+	err = f.Run(strings.NewReader(text), &buf)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"secret":"x"`)
+
+	// Drop the top level
+	buf.Reset()
+	f = NewFilter()
+	f.Drop("")
+	err = f.Run(strings.NewReader(`{"a": 1}`), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), `null`)
+
+	// Mask the top level
+	buf.Reset()
+	f = NewFilter()
+	f.Mask("", "***")
+	err = f.Run(strings.NewReader(`[1]`), &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), `"***"`)
+
+	// Stop by error
+	stop := errors.New("stop")
+	f = NewFilter()
+	f.Replace("a.*", func(path string, value *Json) (interface{}, error) {
+		return nil, stop
+	})
+	err = f.Run(strings.NewReader(`{"a": [1]}`), &buf)
+	assert.Equal(t, err, stop)
+
+	// Invalid json
+	f = NewFilter()
+	f.Mask("a.b", 1)
+	f.Drop("a.c")
+	f.Drop("b.0")
+	f.Replace("a.d", func(path string, value *Json) (interface{}, error) {
+		return value, nil
+	})
+	for _, v := range []string{``, `{"a": {"b": }}`, `{"a": {"c": }}`, `{"a": {"d": }}`, `{"a": {"e": }}`,
+		`{"b": [}`, `{"b": [1, }`, `{"a": {"e": 1`, `{"a": [1, 2`, `{"a": [`, `{"a": 1`} {
+		buf.Reset()
+		err = f.Run(strings.NewReader(v), &buf)
+		assert.NotNil(t, err, v)
+	}
+}
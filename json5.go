@@ -0,0 +1,314 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LoadsJSON5 unmarshal JSON5 from string, returns json object
+//   LoadsJSON5(`{name: 'Li Kexian', mask: 0xFF, /* comment */}`)
+func LoadsJSON5(text string) (*Json, error) {
+	j := New()
+	err := j.LoadsJSON5(text)
+	return j, err
+}
+
+// DumpsJSON5 marshal json object to JSON5 string
+func DumpsJSON5(data interface{}) (string, error) {
+	return New(data).DumpsJSON5()
+}
+
+// LoadsJSON5 unmarshal JSON5 from string, the same tree as Loads is returned,
+// numbers are normalized to json.Number, except that Infinity and NaN are float64,
+// unquoted keys, single quoted strings, hex numbers, Infinity and NaN, leading and trailing
+// decimal point, plus sign, multi-line strings, comments and trailing commas are supported
+func (j *Json) LoadsJSON5(text string) error {
+	j.positions = nil
+	j.source = nil
+	j.duplicates = nil
+	return j.parse([]byte(text), true)
+}
+
+// DumpsJSON5 marshal json object to JSON5 string
+// keys of identifier are unquoted, and Infinity and NaN are dumped as it is
+//   DumpsJSON5(map[string]interface{}{"max": math.Inf(1)}) // {max:Infinity}
+func (j *Json) DumpsJSON5() (string, error) {
+	e := &encoder{
+		escapeHtml: j.escapeHtml,
+		json5:      true,
+		nonFinite:  j.nonFinite,
+		base:       j.path,
+	}
+
+	err := e.encode(reflect.ValueOf(j.data))
+	if err != nil {
+		return "", err
+	}
+
+	if j.asciiEscape {
+		return string(escapeASCII(e.buf.Bytes())), nil
+	}
+
+	return e.buf.String(), nil
+}
+
+// key5 parses a JSON5 object key, quoted string or identifier
+func (p *parser) key5() (interface{}, error) {
+	c := p.data[p.pos]
+	if c == '"' || c == '\'' {
+		return p.string()
+	}
+
+	start := p.pos
+	buf := []byte{}
+	for p.pos < len(p.data) {
+		if err := p.checkString(start, buf); err != nil {
+			return nil, err
+		}
+		r, n := utf8.DecodeRune(p.data[p.pos:])
+		if r == '\\' && p.pos+1 < len(p.data) && p.data[p.pos+1] == 'u' {
+			e, err := p.hex4(p.pos + 2)
+			if err != nil {
+				return nil, err
+			}
+			r, n = e, 6
+		}
+		if !isIdentifierRune(r, len(buf) == 0) {
+			break
+		}
+		buf = append(buf, string(r)...)
+		p.pos += n
+	}
+
+	if err := p.checkString(start, buf); err != nil {
+		return nil, err
+	}
+
+	if len(buf) == 0 {
+		return nil, p.errorf("invalid character %s looking for beginning of object key", quoteChar(c))
+	}
+
+	return string(buf), nil
+}
+
+// escape5 parses the JSON5 escape at pos which is not in json, appends it to buf
+func (p *parser) escape5(buf []byte) ([]byte, error) {
+	e := p.data[p.pos+1]
+	switch {
+	case e == '\'':
+		buf = append(buf, '\'')
+		p.pos += 2
+	case e == 'v':
+		buf = append(buf, '\v')
+		p.pos += 2
+	case e == '0':
+		if p.pos+2 < len(p.data) && isDigit(p.data[p.pos+2]) {
+			p.pos += 2
+			return nil, p.errorf("invalid character %s in string escape code", quoteChar(p.data[p.pos]))
+		}
+		buf = append(buf, 0)
+		p.pos += 2
+	case e == 'x':
+		for k := p.pos + 2; k < p.pos+4; k++ {
+			if k >= len(p.data) {
+				p.pos = len(p.data)
+				return nil, p.eof()
+			}
+			if !isHex(p.data[k]) {
+				p.pos = k
+				return nil, p.errorf("invalid character %s in \\x hexadecimal character escape", quoteChar(p.data[k]))
+			}
+		}
+		r, _ := strconv.ParseUint(string(p.data[p.pos+2:p.pos+4]), 16, 8)
+		buf = append(buf, string(rune(r))...)
+		p.pos += 4
+	case e == '\n':
+		p.pos += 2
+	case e == '\r':
+		p.pos += 2
+		if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+			p.pos++
+		}
+	case e >= '1' && e <= '9':
+		p.pos++
+		return nil, p.errorf("invalid character %s in string escape code", quoteChar(e))
+	default:
+		r, n := utf8.DecodeRune(p.data[p.pos+1:])
+		if r != '\u2028' && r != '\u2029' {
+			buf = append(buf, string(r)...)
+		}
+		p.pos += 1 + n
+	}
+
+	return buf, nil
+}
+
+// number5 parses a JSON5 number, returns json.Number, or float64 of Infinity and NaN
+func (p *parser) number5() (interface{}, error) {
+	begin := p.pos
+	neg := p.data[p.pos] == '-'
+	if p.data[p.pos] == '+' || neg {
+		p.pos++
+	}
+
+	rest := p.data[p.pos:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("Infinity")):
+		p.pos += len("Infinity")
+		if neg {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case bytes.HasPrefix(rest, []byte("NaN")):
+		p.pos += len("NaN")
+		return math.NaN(), nil
+	case bytes.HasPrefix(rest, []byte("0x")) || bytes.HasPrefix(rest, []byte("0X")):
+		p.pos += 2
+		start := p.pos
+		for p.pos < len(p.data) && isHex(p.data[p.pos]) {
+			p.pos++
+		}
+		if p.pos == start {
+			return nil, p.numberError("in hexadecimal numeric literal")
+		}
+		n, _ := new(big.Int).SetString(string(p.data[start:p.pos]), 16)
+		if neg {
+			n.Neg(n)
+		}
+		if err := p.checkNumber(begin); err != nil {
+			return nil, err
+		}
+		return json.Number(n.String()), nil
+	}
+
+	start := p.pos
+	p.skipDigits()
+	result := string(p.data[start:p.pos])
+	if len(result) > 1 && result[0] == '0' {
+		p.pos = start + 1
+		return nil, p.numberError("after leading zero in numeric literal")
+	}
+
+	if p.pos < len(p.data) && p.data[p.pos] == '.' {
+		p.pos++
+		start := p.pos
+		p.skipDigits()
+		if p.pos > start {
+			result += "." + string(p.data[start:p.pos])
+		}
+	}
+
+	if result == "" || result[0] == '.' {
+		if result == "" {
+			return nil, p.numberError("in numeric literal")
+		}
+		result = "0" + result
+	}
+
+	if p.pos < len(p.data) && (p.data[p.pos] == 'e' || p.data[p.pos] == 'E') {
+		start := p.pos
+		p.pos++
+		if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+			p.pos++
+		}
+		if p.pos >= len(p.data) || !isDigit(p.data[p.pos]) {
+			return nil, p.numberError("in exponent of numeric literal")
+		}
+		p.skipDigits()
+		result += string(p.data[start:p.pos])
+	}
+
+	if neg {
+		result = "-" + result
+	}
+
+	err := p.checkNumber(begin)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Number(result), nil
+}
+
+// numberError returns the error of invalid character at pos in number, or unexpected end
+func (p *parser) numberError(msg string) error {
+	if p.pos >= len(p.data) {
+		return p.eof()
+	}
+
+	return p.errorf("invalid character %s %s", quoteChar(p.data[p.pos]), msg)
+}
+
+// space5 skips a JSON5 whitespace which is not in json, returns whether skipped
+func (p *parser) space5() bool {
+	c := p.data[p.pos]
+	if c == '\v' || c == '\f' {
+		p.pos++
+		return true
+	}
+
+	if c < utf8.RuneSelf {
+		return false
+	}
+
+	r, n := utf8.DecodeRune(p.data[p.pos:])
+	if r == '\uFEFF' || r == '\u2028' || r == '\u2029' || unicode.Is(unicode.Zs, r) {
+		p.pos += n
+		return true
+	}
+
+	return false
+}
+
+// isIdentifierRune returns whether r is a character of JSON5 identifier, first is for the first character
+func isIdentifierRune(r rune, first bool) bool {
+	if r == '_' || r == '$' || unicode.IsLetter(r) || unicode.Is(unicode.Nl, r) {
+		return true
+	}
+
+	if first {
+		return false
+	}
+
+	return unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc, unicode.Pc) || r == '\u200C' || r == '\u200D'
+}
+
+// isIdentifierName returns whether text is a JSON5 identifier
+func isIdentifierName(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for i, r := range text {
+		if !isIdentifierRune(r, i == 0) {
+			return false
+		}
+	}
+
+	return true
+}
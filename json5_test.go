@@ -0,0 +1,124 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+var textJSON5 = `// JSON5 config
+{
+    name: 'Li Kexian',
+    "quoted": "it's",
+    'single': 'say "hi"\x21',
+    $id_1: 0x1F,
+    neg: -0XFF,
+    plus: +1,
+    lead: .5,
+    trail: 5.,
+    exp: -.5e+2,
+    inf: Infinity,
+    ninf: -Infinity,
+    nan: NaN,
+    lines: 'line 1 \
+line 2',
+    escape: '\v\0\a\'',
+    中文: [1, 2, /* two */],
+}
+`
+
+func Test_Loads_JSON5(t *testing.T) {
+	j, err := LoadsJSON5(textJSON5)
+	assert.Nil(t, err)
+
+	assert.Equal(t, j.Get("name").MustString(), "Li Kexian")
+	assert.Equal(t, j.Get("quoted").MustString(), "it's")
+	assert.Equal(t, j.Get("single").MustString(), `say "hi"!`)
+	assert.Equal(t, j.Get("$id_1").MustInt(), 31)
+	assert.Equal(t, j.Get("neg").MustInt(), -255)
+	assert.Equal(t, j.Get("plus").MustInt(), 1)
+	assert.Equal(t, j.Get("lead").MustFloat64(), 0.5)
+	assert.Equal(t, j.Get("trail").MustFloat64(), 5.0)
+	assert.Equal(t, j.Get("exp").MustFloat64(), -50.0)
+	assert.True(t, math.IsInf(j.Get("inf").MustFloat64(), 1))
+	assert.True(t, math.IsInf(j.Get("ninf").MustFloat64(), -1))
+	assert.True(t, math.IsNaN(j.Get("nan").MustFloat64()))
+	assert.Equal(t, j.Get("lines").MustString(), "line 1 line 2")
+	assert.Equal(t, j.Get("escape").MustString(), "\v\x00a'")
+	assert.Equal(t, j.Get("中文").MustArray(), []interface{}{json.Number("1"), json.Number("2")})
+
+	j.Del("inf")
+	j.Del("ninf")
+	j.Del("nan")
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"$id_1":31,"escape":"\u000b\u0000a'","exp":-0.5e+2,"lead":0.5,`+
+		`"lines":"line 1 line 2","name":"Li Kexian","neg":-255,"plus":1,"quoted":"it's",`+
+		`"single":"say \"hi\"!","trail":5,"中文":[1,2]}`)
+
+	for _, v := range textParser {
+		expect, err := Loads(v)
+		assert.Nil(t, err, v)
+		es, err := expect.Dumps()
+		assert.Nil(t, err, v)
+
+		j, err := LoadsJSON5(v)
+		assert.Nil(t, err, v)
+		s, err := j.Dumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, es, v)
+	}
+
+	invalid := []string{
+		``, `{`, `{a}`, `{a:}`, `{1:1}`, `{-a:1}`, `'a`, `"a'`, `'\1'`, `'\01'`, `'\x1'`, `'\xzz'`, `'a
+b'`, `0x`, `0xg`, `+`, `-`, `.`, `.e1`, `1e`, `1e+`, `01`, `Inf`, `[NaNa]`, `[1,,]`, `{a:1,,}`, `[1 2]`, `/* a`,
+	}
+
+	for _, v := range invalid {
+		_, err := LoadsJSON5(v)
+		assert.NotNil(t, err, v)
+		_, ok := err.(*SyntaxError)
+		assert.True(t, ok, v)
+	}
+}
+
+func Test_Dumps_JSON5(t *testing.T) {
+	j, err := LoadsJSON5(textJSON5)
+	assert.Nil(t, err)
+
+	s, err := j.DumpsJSON5()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{$id_1:31,escape:"\u000b\u0000a'",exp:-0.5e+2,inf:Infinity,lead:0.5,`+
+		`lines:"line 1 line 2",name:"Li Kexian",nan:NaN,neg:-255,ninf:-Infinity,plus:1,quoted:"it's",`+
+		`single:"say \"hi\"!",trail:5,中文:[1,2]}`)
+
+	r, err := LoadsJSON5(s)
+	assert.Nil(t, err)
+	assert.Equal(t, r.Get("name").MustString(), "Li Kexian")
+	assert.True(t, math.IsNaN(r.Get("nan").MustFloat64()))
+
+	s, err = DumpsJSON5(map[string]interface{}{"a-b": []float64{1.5, math.Inf(-1)}, "": "<"})
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"":"<","a-b":[1.5,-Infinity]}`)
+}
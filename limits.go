@@ -0,0 +1,82 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/likexian/gokit/xfile"
+)
+
+// maxDepth is the max nesting depth of arrays and objects always applied, as encoding/json
+const maxDepth = 10000
+
+// Limits is the limits of data for loading, zero is no limit
+//   MaxSize:         the max bytes of data
+//   MaxDepth:        the max nesting depth of arrays and objects, no more than 10000 as encoding/json
+//   MaxStringLength: the max bytes of string and key after unescaped
+//   MaxArrayLength:  the max number of array elements
+//   MaxObjectLength: the max number of object members
+//   MaxNumberLength: the max bytes of number literal
+type Limits struct {
+	MaxSize         int
+	MaxDepth        int
+	MaxStringLength int
+	MaxArrayLength  int
+	MaxObjectLength int
+	MaxNumberLength int
+}
+
+// SetLimits set the limits for loading untrusted data, there is no limit by default
+// when data exceeds the limits, *SyntaxError of ErrLimitExceeded is returned,
+// reader and file are read no more than MaxSize+1 bytes
+//   json := New()
+//   json.SetLimits(Limits{MaxSize: 1 << 20, MaxDepth: 32})
+//   err := json.LoadReader(r)
+//   if e, ok := err.(*SyntaxError); ok && e.Err == ErrLimitExceeded { ... }
+func (j *Json) SetLimits(limits Limits) {
+	j.limits = limits
+}
+
+// limitf returns the *SyntaxError of ErrLimitExceeded at offset
+func (p *parser) limitf(offset int, format string, args ...interface{}) error {
+	e := newSyntaxError(p.data, offset, fmt.Sprintf(format, args...))
+	e.Err = ErrLimitExceeded
+	return e
+}
+
+// readFile reads the file, no more than max+1 bytes if max is not zero
+func readFile(path string, max int) ([]byte, error) {
+	if max <= 0 {
+		return xfile.Read(path)
+	}
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	return ioutil.ReadAll(io.LimitReader(fd, int64(max)+1))
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+// repeatReader is an endless reader of byte c
+type repeatReader struct {
+	c byte
+	n int
+}
+
+// Read reads the byte c into p
+func (r *repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.c
+	}
+	r.n += len(p)
+	return len(p), nil
+}
+
+func Test_Limits(t *testing.T) {
+	tests := []struct {
+		limits Limits
+		valid  string
+		text   string
+		err    string
+	}{
+		{Limits{MaxSize: 10}, `[1, 2, 33]`, `[1, 2, 333]`, "1:11: exceeded max size of 10"},
+		{Limits{MaxDepth: 2}, `[{"a": 1}]`, `[{"a": []}]`, "1:8: exceeded max depth of 2"},
+		{Limits{MaxDepth: 1}, `{"a": 1}`, `[[]]`, "1:2: exceeded max depth of 1"},
+		{Limits{MaxStringLength: 3}, `{"abc": "中"}`, `["中中"]`, "1:2: exceeded max string length of 3"},
+		{Limits{MaxStringLength: 3}, `{"abc": "abc"}`, `{"abcd": 1}`, "1:2: exceeded max string length of 3"},
+		{Limits{MaxArrayLength: 2}, `[1, [2, 3]]`, `[1, [2, 3, 4]]`, "1:12: exceeded max array length of 2"},
+		{Limits{MaxObjectLength: 1}, `{"a": {"b": 1}}`, `{"a": 1, "b": 2}`, "1:10: exceeded max object length of 1"},
+		{Limits{MaxNumberLength: 4}, `[-1.5, 1e10]`, `[1, 1.234]`, "1:5: exceeded max number length of 4"},
+	}
+
+	for _, v := range tests {
+		j := New()
+		j.SetLimits(v.limits)
+		err := j.Loads(v.valid)
+		assert.Nil(t, err, v.valid)
+
+		err = j.Loads(v.text)
+		assert.NotNil(t, err, v.text)
+		assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded, v.text)
+		assert.Equal(t, err.Error(), "simplejson: "+v.err, v.text)
+
+		err = j.LoadReader(strings.NewReader(v.text))
+		assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded, v.text)
+	}
+
+	j := New()
+	j.SetLimits(Limits{MaxNumberLength: 4})
+	err := j.LoadsJSON5(`[0xFF]`)
+	assert.Nil(t, err)
+	err = j.LoadsJSON5(`[0xFFF]`)
+	assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+	err = j.LoadsJSON5(`[-1.25]`)
+	assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+
+	r := &repeatReader{c: ' '}
+	j = New()
+	j.SetLimits(Limits{MaxSize: 100})
+	err = j.LoadReader(r)
+	assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+	assert.True(t, r.n < 10000)
+
+	err = j.Loads(`"` + strings.Repeat("a", 98) + `"`)
+	assert.Nil(t, err)
+
+	// String is checked while parsing, unterminated string is an error of limit
+	for _, v := range []string{`["` + strings.Repeat("a", 1<<20), `["` + strings.Repeat(`\u4e2d`, 1<<10) + `"]`} {
+		j = New()
+		j.SetLimits(Limits{MaxStringLength: 10})
+		err = j.Loads(v)
+		assert.Equal(t, err.Error(), "simplejson: 1:2: exceeded max string length of 10")
+		assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+	}
+
+	j = New()
+	j.SetLimits(Limits{MaxStringLength: 3})
+	err = j.LoadsJSON5(`{abc: 1}`)
+	assert.Nil(t, err)
+	err = j.LoadsJSON5(`{abcd: 1}`)
+	assert.Equal(t, err.Error(), "simplejson: 1:2: exceeded max string length of 3")
+}
+
+func Test_Limits_Load(t *testing.T) {
+	defer os.Remove("limits.json")
+
+	err := xfile.WriteText("limits.json", `{"name": "`+strings.Repeat("a", 1000)+`"}`)
+	assert.Nil(t, err)
+
+	j := New()
+	j.SetLimits(Limits{MaxSize: 100})
+	err = j.Load("limits.json")
+	assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+	assert.Equal(t, err.(*SyntaxError).File, "limits.json")
+	assert.Equal(t, err.(*SyntaxError).Offset, int64(100))
+
+	j.SetLimits(Limits{MaxSize: 2000})
+	err = j.Load("limits.json")
+	assert.Nil(t, err)
+	assert.Equal(t, len(j.Get("name").MustString()), 1000)
+
+	err = j.Load("not-exists.json")
+	assert.NotNil(t, err)
+}
+
+func Test_Limits_MaxDepth(t *testing.T) {
+	deep := strings.Repeat("[", maxDepth*10)
+	nested := strings.Repeat("[", maxDepth) + strings.Repeat("]", maxDepth)
+	over := "[" + nested + "]"
+
+	loaders := map[string]func(text string) error{
+		"Partial": func(text string) error {
+			_, _, err := LoadsPartial(text)
+			return err
+		},
+		"JSON5": func(text string) error {
+			_, err := LoadsJSON5(text)
+			return err
+		},
+		"Repair": func(text string) error {
+			_, _, err := Repair(text)
+			return err
+		},
+	}
+
+	options := map[string]func(j *Json){
+		"Ordered":     func(j *Json) { j.SetOrdered(true) },
+		"JSONC":       func(j *Json) { j.SetJSONC(true) },
+		"Position":    func(j *Json) { j.SetPosition(true) },
+		"Duplicate":   func(j *Json) { j.SetDuplicateKey(DuplicateError) },
+		"InvalidUTF8": func(j *Json) { j.SetInvalidUTF8(InvalidUTF8Error) },
+		"NonFinite":   func(j *Json) { j.SetNonFinite(NonFiniteLiteral) },
+		"Limits":      func(j *Json) { j.SetLimits(Limits{MaxSize: 1 << 30}) },
+		"MaxDepth":    func(j *Json) { j.SetLimits(Limits{MaxDepth: maxDepth * 2}) },
+	}
+
+	for k, v := range options {
+		set := v
+		loaders[k] = func(text string) error {
+			j := New()
+			set(j)
+			return j.Loads(text)
+		}
+	}
+
+	for k, load := range loaders {
+		err := load(deep)
+		assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded, k)
+		assert.Equal(t, err.(*SyntaxError).Msg, "exceeded max depth of 10000", k)
+		assert.Equal(t, err.(*SyntaxError).Offset, int64(maxDepth), k)
+
+		err = load(over)
+		assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded, k)
+
+		err = load(nested)
+		assert.Nil(t, err, k)
+	}
+}
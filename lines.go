@@ -0,0 +1,240 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// seqSeparator is the record separator of RFC 7464 JSON text sequences
+const seqSeparator = 0x1E
+
+// LinesReader reads json objects from JSON Lines (NDJSON), one json object per line
+//   r := NewLinesReader(reader)
+//   for r.Next() {
+//       fmt.Println(r.Line(), r.Json().Get("id").MustInt())
+//   }
+//   if err := r.Err(); err != nil {
+//       return err
+//   }
+type LinesReader struct {
+	*recordReader
+}
+
+// SeqReader reads json objects from RFC 7464 JSON text sequences (application/json-seq)
+// each json object is prefixed by a record separator (0x1E), the usage is the same as LinesReader,
+// except that truncated or malformed records are skipped as RFC 7464, and could be got by Skipped
+type SeqReader struct {
+	*recordReader
+}
+
+// LinesWriter writes json objects as JSON Lines (NDJSON), one json object per line
+//   w := NewLinesWriter(writer)
+//   err := w.Encode(map[string]interface{}{"id": 1})
+type LinesWriter struct {
+	*recordWriter
+}
+
+// SeqWriter writes json objects as RFC 7464 JSON text sequences (application/json-seq)
+type SeqWriter struct {
+	*recordWriter
+}
+
+// recordReader reads json objects from records separated by delim
+type recordReader struct {
+	reader *bufio.Reader
+	delim  byte
+	json   *Json
+	lines  int
+	line   int
+	// column is the count of characters read in the current line
+	column int
+	// offset is the count of bytes read
+	offset int
+	err    error
+	// skip skips the invalid records instead of stopping with error
+	skip bool
+	// skipped is the errors of skipped records
+	skipped []error
+}
+
+// recordWriter writes json objects as records with prefix and suffix
+type recordWriter struct {
+	writer io.Writer
+	prefix []byte
+	suffix []byte
+}
+
+// NewLinesReader returns a new LinesReader reading from r
+func NewLinesReader(r io.Reader) *LinesReader {
+	return &LinesReader{newRecordReader(r, '\n')}
+}
+
+// NewSeqReader returns a new SeqReader reading from r
+func NewSeqReader(r io.Reader) *SeqReader {
+	reader := newRecordReader(r, seqSeparator)
+	reader.skip = true
+	return &SeqReader{reader}
+}
+
+// NewLinesWriter returns a new LinesWriter writing to w
+func NewLinesWriter(w io.Writer) *LinesWriter {
+	return &LinesWriter{newRecordWriter(w, nil, []byte{'\n'})}
+}
+
+// NewSeqWriter returns a new SeqWriter writing to w
+func NewSeqWriter(w io.Writer) *SeqWriter {
+	return &SeqWriter{newRecordWriter(w, []byte{seqSeparator}, []byte{'\n'})}
+}
+
+// newRecordReader returns a new recordReader reading from r
+func newRecordReader(r io.Reader, delim byte) *recordReader {
+	return &recordReader{
+		reader: bufio.NewReader(r),
+		delim:  delim,
+	}
+}
+
+// newRecordWriter returns a new recordWriter writing to w
+func newRecordWriter(w io.Writer, prefix, suffix []byte) *recordWriter {
+	return &recordWriter{
+		writer: w,
+		prefix: prefix,
+		suffix: suffix,
+	}
+}
+
+// Next reads the next json object, returns false if there is no more or error
+// blank records are skipped
+func (r *recordReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	for {
+		data, err := r.reader.ReadBytes(r.delim)
+		if err != nil && err != io.EOF {
+			r.err = err
+			return false
+		}
+
+		if len(data) == 0 && err == io.EOF {
+			return false
+		}
+
+		text := bytes.TrimLeft(data, " \t\r\n")
+		leading := data[:len(data)-len(text)]
+		r.line = r.lines + 1 + bytes.Count(leading, []byte{'\n'})
+		column, offset := advanceColumn(r.column, leading), r.offset+len(leading)
+		r.lines += bytes.Count(data, []byte{'\n'})
+		r.column = advanceColumn(r.column, data)
+		r.offset += len(data)
+
+		body := bytes.TrimSuffix(text, []byte{r.delim})
+		text = bytes.TrimSpace(body)
+		if len(text) == 0 {
+			if err == io.EOF {
+				return false
+			}
+			continue
+		}
+
+		j := New()
+		j.SetStrict(true)
+		e := j.LoadBytes(text)
+		if e == nil && r.skip && len(body) == len(text) && bytes.IndexByte([]byte("{[\""), text[0]) < 0 {
+			// RFC 7464: number, true, false and null not followed by whitespace are possibly truncated
+			e = newSyntaxError(text, len(text), "possibly truncated top-level value without trailing whitespace")
+		}
+		if e != nil {
+			if s, ok := e.(*SyntaxError); ok {
+				if s.Line == 1 {
+					s.Column += column
+				}
+				s.Line += r.line - 1
+				s.Offset += int64(offset)
+			}
+			if r.skip {
+				r.skipped = append(r.skipped, &LineError{Line: r.line, Err: e})
+				continue
+			}
+			r.err = &LineError{Line: r.line, Err: e}
+			return false
+		}
+
+		r.json = j
+
+		return true
+	}
+}
+
+// Json returns the json object read by the last Next
+func (r *recordReader) Json() *Json {
+	return r.json
+}
+
+// Line returns the line number where the json object read by the last Next starts, starts from 1
+func (r *recordReader) Line() int {
+	return r.line
+}
+
+// Err returns the error occurred while reading, nil if reached the end
+func (r *recordReader) Err() error {
+	return r.err
+}
+
+// advanceColumn returns the count of characters in the current line after reading data from column
+func advanceColumn(column int, data []byte) int {
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		column, data = 0, data[i+1:]
+	}
+
+	return column + utf8.RuneCount(data)
+}
+
+// Skipped returns the *LineError of truncated or malformed records skipped so far
+func (r *SeqReader) Skipped() []error {
+	return r.skipped
+}
+
+// Encode writes data as a record, data can be *Json or any data could be used by New
+func (w *recordWriter) Encode(data interface{}) error {
+	j, ok := data.(*Json)
+	if !ok {
+		j = New(data)
+	}
+
+	result, err := j.DumpBytes()
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, 0, len(w.prefix)+len(result)+len(w.suffix))
+	record = append(record, w.prefix...)
+	record = append(record, result...)
+	record = append(record, w.suffix...)
+
+	_, err = w.writer.Write(record)
+
+	return err
+}
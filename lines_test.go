@@ -0,0 +1,222 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+type errorReader struct{}
+
+func (r errorReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+type errorWriter struct{}
+
+func (w errorWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_Lines_Reader(t *testing.T) {
+	text := "{\"id\":1}\n\n  {\"id\":2}  \r\n[3]\n\"4\""
+
+	r := NewLinesReader(strings.NewReader(text))
+	ids := []int{}
+	lines := []int{}
+	for r.Next() {
+		j := r.Json()
+		if j.IsArray() {
+			ids = append(ids, j.Index(0).MustInt())
+		} else if j.IsMap() {
+			ids = append(ids, j.Get("id").MustInt())
+		} else {
+			j.SetWeakType(true)
+			ids = append(ids, j.MustInt())
+		}
+		lines = append(lines, r.Line())
+	}
+	assert.Nil(t, r.Err())
+	assert.Equal(t, ids, []int{1, 2, 3, 4})
+	assert.Equal(t, lines, []int{1, 3, 4, 5})
+	assert.False(t, r.Next())
+
+	// Trailing newline and blank lines
+	r = NewLinesReader(strings.NewReader("{}\n\n"))
+	assert.True(t, r.Next())
+	assert.False(t, r.Next())
+	assert.Nil(t, r.Err())
+
+	// Empty input
+	r = NewLinesReader(strings.NewReader(""))
+	assert.False(t, r.Next())
+	assert.Nil(t, r.Err())
+
+	// Error with line number
+	r = NewLinesReader(strings.NewReader("{\"id\":1}\n{\"id\":\n{\"id\":3}"))
+	assert.True(t, r.Next())
+	assert.False(t, r.Next())
+	assert.False(t, r.Next())
+	err := r.Err()
+	assert.NotNil(t, err)
+	assert.Equal(t, err.(*LineError).Line, 2)
+	assert.Equal(t, err.(*LineError).Unwrap(), err.(*LineError).Err)
+	assert.Equal(t, err.Error(), "simplejson: 2:7: unexpected end of JSON input")
+	assert.Equal(t, err.(*LineError).Err.(*SyntaxError).Offset, int64(15))
+
+	// Position of syntax error in the whole input
+	r = NewLinesReader(strings.NewReader("{\"id\":1}\n\n  {\"id\": x}\n"))
+	assert.True(t, r.Next())
+	assert.False(t, r.Next())
+	assert.Equal(t, r.Err().(*LineError).Line, 3)
+	assert.Equal(t, r.Err().Error(), "simplejson: 3:10: invalid character 'x' looking for beginning of value")
+
+	// Other error is prefixed with the line number
+	assert.Equal(t, (&LineError{Line: 2, Err: io.ErrUnexpectedEOF}).Error(), "simplejson: line 2: unexpected EOF")
+
+	// Trailing data in line
+	r = NewLinesReader(strings.NewReader("{\"id\":1}\n{\"id\":2} {\"id\":3}"))
+	assert.True(t, r.Next())
+	assert.False(t, r.Next())
+	assert.Equal(t, r.Err().(*LineError).Line, 2)
+
+	// Read error
+	r = NewLinesReader(errorReader{})
+	assert.False(t, r.Next())
+	assert.NotNil(t, r.Err())
+}
+
+func Test_Lines_Writer(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewLinesWriter(&buf)
+	err := w.Encode(map[string]interface{}{"id": 1, "name": "<a>"})
+	assert.Nil(t, err)
+
+	j := New()
+	j.Set("id", 2)
+	j.Set("name", "<b>")
+	j.SetHtmlEscape(true)
+	err = w.Encode(j)
+	assert.Nil(t, err)
+
+	err = w.Encode([]int{3})
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "{\"id\":1,\"name\":\"<a>\"}\n{\"id\":2,\"name\":\"\\u003cb\\u003e\"}\n[3]\n")
+
+	// Read the written lines
+	r := NewLinesReader(&buf)
+	n := 0
+	for r.Next() {
+		n++
+	}
+	assert.Nil(t, r.Err())
+	assert.Equal(t, n, 3)
+
+	// Write errors
+	err = w.Encode(make(chan int))
+	assert.NotNil(t, err)
+	err = NewLinesWriter(errorWriter{}).Encode(1)
+	assert.NotNil(t, err)
+}
+
+func Test_Seq_Reader_Writer(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := NewSeqWriter(&buf)
+	err := w.Encode(map[string]interface{}{"id": 1})
+	assert.Nil(t, err)
+	err = w.Encode([]int{2})
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "\x1e{\"id\":1}\n\x1e[2]\n")
+
+	// Pretty printed records across lines
+	buf.WriteString("\x1e{\n    \"id\": 3\n}\n\x1e\n\x1e 4 \n")
+
+	r := NewSeqReader(&buf)
+	ids := []int{}
+	lines := []int{}
+	for r.Next() {
+		j := r.Json()
+		if j.IsMap() {
+			ids = append(ids, j.Get("id").MustInt())
+		} else if j.IsArray() {
+			ids = append(ids, j.Index(0).MustInt())
+		} else {
+			ids = append(ids, j.MustInt())
+		}
+		lines = append(lines, r.Line())
+	}
+	assert.Nil(t, r.Err())
+	assert.Equal(t, ids, []int{1, 2, 3, 4})
+	assert.Equal(t, lines, []int{1, 2, 3, 7})
+
+	// Truncated and malformed records are skipped
+	r = NewSeqReader(strings.NewReader("\x1e{\"id\":1}\n\x1e{\"id\"\x1e{\"id\":3}\n\x1e{x}\n\x1e[4]\n"))
+	ids = []int{}
+	for r.Next() {
+		if r.Json().IsArray() {
+			ids = append(ids, r.Json().Index(0).MustInt())
+		} else {
+			ids = append(ids, r.Json().Get("id").MustInt())
+		}
+	}
+	assert.Nil(t, r.Err())
+	assert.Equal(t, ids, []int{1, 3, 4})
+	assert.Equal(t, len(r.Skipped()), 2)
+	assert.Equal(t, r.Skipped()[0].(*LineError).Line, 2)
+	assert.Equal(t, r.Skipped()[1].(*LineError).Line, 3)
+	assert.Equal(t, r.Skipped()[0].Error(), "simplejson: 2:7: unexpected end of JSON input")
+	assert.Equal(t, r.Skipped()[1].Error(), "simplejson: 3:3: invalid character 'x' looking for beginning of object key string")
+
+	// Scalar without trailing whitespace is possibly truncated
+	r = NewSeqReader(strings.NewReader("\x1e1\n\x1etrue\x1e\"a\"\x1e[2]\x1e 3\n\x1e12"))
+	ids = []int{}
+	for r.Next() {
+		if r.Json().IsArray() {
+			ids = append(ids, r.Json().Index(0).MustInt())
+		} else if s, err := r.Json().String(); err == nil {
+			ids = append(ids, len(s))
+		} else {
+			ids = append(ids, r.Json().MustInt())
+		}
+	}
+	assert.Nil(t, r.Err())
+	assert.Equal(t, ids, []int{1, 1, 2, 3})
+	assert.Equal(t, len(r.Skipped()), 2)
+	assert.Equal(t, r.Skipped()[0].Error(), "simplejson: 2:6: possibly truncated top-level value without trailing whitespace")
+	assert.Equal(t, r.Skipped()[1].(*LineError).Line, 3)
+
+	// Read error stops the sequence
+	r = NewSeqReader(errorReader{})
+	assert.False(t, r.Next())
+	assert.NotNil(t, r.Err())
+	assert.Equal(t, len(r.Skipped()), 0)
+
+	// Write error
+	err = NewSeqWriter(errorWriter{}).Encode(1)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"math"
+)
+
+// NonFinite is the policy of float Infinity and NaN for dumping and loading
+type NonFinite int
+
+// NonFinite values, if not set dumping returns *json.UnsupportedValueError as encoding/json
+const (
+	// NonFiniteError returns *PathError of ErrNonFinite with the path of value
+	NonFiniteError NonFinite = iota + 1
+	// NonFiniteNull dumps them as null
+	NonFiniteNull
+	// NonFiniteString dumps them as string "NaN", "Infinity" and "-Infinity",
+	// and Float64 accepts these strings
+	NonFiniteString
+	// NonFiniteLiteral dumps them as JSON5 literal NaN, Infinity and -Infinity,
+	// and loading accepts these literals as float64
+	NonFiniteLiteral
+)
+
+// SetNonFinite set the policy of float Infinity and NaN for dumping and loading, it is not set by default
+//   json := New(map[string]interface{}{"rate": math.NaN()})
+//   json.SetNonFinite(NonFiniteNull)
+//   json.Dumps() // {"rate":null}
+func (j *Json) SetNonFinite(policy NonFinite) {
+	j.nonFinite = policy
+}
+
+// nonFiniteText returns the text of float f which is Infinity or NaN
+func nonFiniteText(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	default:
+		return "NaN"
+	}
+}
+
+// parseNonFinite returns the float of text "NaN", "Infinity" or "-Infinity"
+func parseNonFinite(text string) (float64, bool) {
+	switch text {
+	case "NaN":
+		return math.NaN(), true
+	case "Infinity":
+		return math.Inf(1), true
+	case "-Infinity":
+		return math.Inf(-1), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"math"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_NonFinite_Dumps(t *testing.T) {
+	data := map[string]interface{}{
+		"a": math.NaN(),
+		"b": []interface{}{1, math.Inf(1), math.Inf(-1)},
+		"c": "x",
+	}
+
+	_, err := New(data).Dumps()
+	assert.NotNil(t, err)
+
+	tests := []struct {
+		policy NonFinite
+		expect string
+	}{
+		{NonFiniteNull, `{"a":null,"b":[1,null,null],"c":"x"}`},
+		{NonFiniteString, `{"a":"NaN","b":[1,"Infinity","-Infinity"],"c":"x"}`},
+		{NonFiniteLiteral, `{"a":NaN,"b":[1,Infinity,-Infinity],"c":"x"}`},
+	}
+
+	for _, v := range tests {
+		j := New(data)
+		j.SetNonFinite(v.policy)
+		s, err := j.Dumps()
+		assert.Nil(t, err)
+		assert.Equal(t, s, v.expect)
+	}
+
+	// DumpsJSON5 follows the policy too
+	j := New(data)
+	s, err := j.DumpsJSON5()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{a:NaN,b:[1,Infinity,-Infinity],c:"x"}`)
+	j.SetNonFinite(NonFiniteNull)
+	s, err = j.DumpsJSON5()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{a:null,b:[1,null,null],c:"x"}`)
+
+	j.SetNonFinite(NonFiniteError)
+	_, err = j.Dumps()
+	assert.Equal(t, err.(*PathError).Err, ErrNonFinite)
+	assert.Equal(t, err.Error(), "simplejson: Dumps a: unsupported non-finite float")
+
+	j.Set("a", 1.5)
+	_, err = j.Dumps()
+	assert.Equal(t, err.Error(), "simplejson: Dumps b.1: unsupported non-finite float")
+
+	_, err = j.Get("b").Dumps()
+	assert.Equal(t, err.Error(), "simplejson: Dumps b.1: unsupported non-finite float")
+
+	j.Set("b", []interface{}{})
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":1.5,"b":[],"c":"x"}`)
+}
+
+func Test_NonFinite_PrettyDumps(t *testing.T) {
+	data := map[string]interface{}{
+		"a": map[string]interface{}{},
+		"b": []interface{}{1, math.Inf(1), map[string]interface{}{"c": "<d>"}},
+		"e": []interface{}{},
+		"f": []byte("x"),
+		"g": map[string]int{"h": 1},
+	}
+
+	j := New(data)
+	j.SetNonFinite(NonFiniteNull)
+	s, err := j.PrettyDumps()
+	assert.Nil(t, err)
+
+	data["b"].([]interface{})[1] = nil
+	expect, err := New(data).PrettyDumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, expect)
+}
+
+func Test_NonFinite_Loads(t *testing.T) {
+	text := `{"a": NaN, "b": [Infinity, -Infinity, -1]}`
+
+	_, err := Loads(text)
+	assert.NotNil(t, err)
+
+	j := New()
+	j.SetNonFinite(NonFiniteLiteral)
+	err = j.Loads(text)
+	assert.Nil(t, err)
+	assert.True(t, math.IsNaN(j.Get("a").MustFloat64()))
+	assert.True(t, math.IsInf(j.Get("b.0").MustFloat64(), 1))
+	assert.True(t, math.IsInf(j.Get("b.1").MustFloat64(), -1))
+	assert.Equal(t, j.Get("b.2").MustFloat64(), float64(-1))
+
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":NaN,"b":[Infinity,-Infinity,-1]}`)
+
+	for _, v := range []string{`[Inf]`, `[-Inf]`, `[nan]`, `[-NaN]`, `[+Infinity]`} {
+		err = j.Loads(v)
+		assert.NotNil(t, err, v)
+	}
+
+	j = New()
+	j.SetNonFinite(NonFiniteString)
+	err = j.Loads(`{"a": "NaN", "b": ["Infinity", "-Infinity", "inf", 1]}`)
+	assert.Nil(t, err)
+	assert.True(t, math.IsNaN(j.Get("a").MustFloat64()))
+	assert.True(t, math.IsInf(j.Get("b.0").MustFloat64(), 1))
+	assert.True(t, math.IsInf(j.Get("b.1").MustFloat64(), -1))
+	_, err = j.Get("b.2").Float64()
+	assert.NotNil(t, err)
+	assert.Equal(t, j.Get("b.3").MustFloat64(), float64(1))
+
+	_, err = Loads(`"NaN"`)
+	assert.Nil(t, err)
+	_, err = New("NaN").Float64()
+	assert.NotNil(t, err)
+
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":"NaN","b":["Infinity","-Infinity","inf",1]}`)
+}
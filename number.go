@@ -0,0 +1,271 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxExponent is the max exponent of number for Decimal, BigInt and BigRat,
+// which write out all the digits
+const maxExponent = 10000
+
+// Number returns as the number text from json object, the loaded number is returned as it is
+//   Loads(`{"amount": 12.30}`).Get("amount").Number() // 12.30
+func (j *Json) Number() (result string, err error) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
+	case json.Number:
+		result = data.(json.Number).String()
+		if !isNumber(result) {
+			return "", errors.New("invalid number " + strconv.Quote(result))
+		}
+		return result, nil
+	case float32, float64:
+		f := reflect.ValueOf(data).Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", errors.New("invalid number " + nonFiniteText(f))
+		}
+		return formatFloat(f, reflect.TypeOf(data).Bits()), nil
+	case int, int8, int16, int32, int64:
+		return strconv.FormatInt(reflect.ValueOf(data).Int(), 10), nil
+	case uint, uint8, uint16, uint32, uint64:
+		return strconv.FormatUint(reflect.ValueOf(data).Uint(), 10), nil
+	default:
+		return "", errors.New("invalid value type")
+	}
+}
+
+// Decimal returns as the decimal string without exponent from json object,
+// the digits of number are kept, such as 12.30 is 12.30, and 1.5e3 is 1500
+func (j *Json) Decimal() (result string, err error) {
+	text, err := j.Number()
+	if err != nil {
+		return
+	}
+
+	return decimal(text)
+}
+
+// BigInt returns as *big.Int from json object, the number must be an integer,
+// such as 12345678901234567890123, 1.0 or 1e3
+func (j *Json) BigInt() (result *big.Int, err error) {
+	r, err := j.BigRat()
+	if err != nil {
+		return
+	}
+
+	if !r.IsInt() {
+		return nil, errors.New("invalid integer number")
+	}
+
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// BigFloat returns as *big.Float from json object,
+// with the precision of at least 64 bits and enough for all the digits of integer
+func (j *Json) BigFloat() (result *big.Float, err error) {
+	text, err := j.Number()
+	if err != nil {
+		return
+	}
+
+	prec := uint(4 * len(text))
+	if prec < 64 {
+		prec = 64
+	}
+
+	result, _, err = big.ParseFloat(text, 10, prec, big.ToNearestEven)
+
+	return
+}
+
+// BigRat returns as *big.Rat from json object, the exact value of number text
+func (j *Json) BigRat() (result *big.Rat, err error) {
+	text, err := j.Decimal()
+	if err != nil {
+		return
+	}
+
+	result, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, errors.New("invalid number " + strconv.Quote(text))
+	}
+
+	return
+}
+
+// MustNumber returns as the number text from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustNumber(args ...string) string {
+	if len(args) > 1 {
+		panic(j.pathError("MustNumber", ErrTooManyArguments))
+	}
+
+	r, err := j.Number()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustNumber", err))
+}
+
+// MustDecimal returns as the decimal string from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustDecimal(args ...string) string {
+	if len(args) > 1 {
+		panic(j.pathError("MustDecimal", ErrTooManyArguments))
+	}
+
+	r, err := j.Decimal()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustDecimal", err))
+}
+
+// MustBigInt returns as *big.Int from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustBigInt(args ...*big.Int) *big.Int {
+	if len(args) > 1 {
+		panic(j.pathError("MustBigInt", ErrTooManyArguments))
+	}
+
+	r, err := j.BigInt()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustBigInt", err))
+}
+
+// MustBigFloat returns as *big.Float from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustBigFloat(args ...*big.Float) *big.Float {
+	if len(args) > 1 {
+		panic(j.pathError("MustBigFloat", ErrTooManyArguments))
+	}
+
+	r, err := j.BigFloat()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustBigFloat", err))
+}
+
+// MustBigRat returns as *big.Rat from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustBigRat(args ...*big.Rat) *big.Rat {
+	if len(args) > 1 {
+		panic(j.pathError("MustBigRat", ErrTooManyArguments))
+	}
+
+	r, err := j.BigRat()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustBigRat", err))
+}
+
+// isNumber returns whether text is a json number
+func isNumber(text string) bool {
+	p := &parser{data: []byte(text)}
+	if text == "" || text[0] != '-' && !isDigit(text[0]) || !isDigit(text[len(text)-1]) {
+		return false
+	}
+
+	_, err := p.parse()
+
+	return err == nil
+}
+
+// decimal returns the json number text without exponent
+func decimal(text string) (string, error) {
+	sign := ""
+	if text[0] == '-' {
+		sign, text = "-", text[1:]
+	}
+
+	exp := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(text[i+1:], "+"))
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return "", errors.New("number exponent out of range")
+		}
+		exp, text = e, text[:i]
+	}
+
+	digits, point := text, len(text)
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		digits, point = text[:i]+text[i+1:], i
+	}
+
+	point += exp
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+
+	result := strings.TrimLeft(digits[:point], "0")
+	if result == "" {
+		result = "0"
+	}
+	if point < len(digits) {
+		result += "." + digits[point:]
+	}
+
+	return sign + result, nil
+}
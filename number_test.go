@@ -0,0 +1,184 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Number(t *testing.T) {
+	tests := []struct {
+		data    interface{}
+		number  string
+		decimal string
+	}{
+		{json.Number("12.30"), "12.30", "12.30"},
+		{json.Number("-0.0"), "-0.0", "-0.0"},
+		{json.Number("1.5e3"), "1.5e3", "1500"},
+		{json.Number("1.5E+3"), "1.5E+3", "1500"},
+		{json.Number("-1.25e-3"), "-1.25e-3", "-0.00125"},
+		{json.Number("123e-1"), "123e-1", "12.3"},
+		{json.Number("340282366920938463463374607431768211457"), "340282366920938463463374607431768211457",
+			"340282366920938463463374607431768211457"},
+		{1.5, "1.5", "1.5"},
+		{float32(0.1), "0.1", "0.1"},
+		{1e21, "1e+21", "1000000000000000000000"},
+		{1e6, "1000000", "1000000"},
+		{1e-7, "1e-7", "0.0000001"},
+		{-12, "-12", "-12"},
+		{uint64(math.MaxUint64), "18446744073709551615", "18446744073709551615"},
+	}
+
+	for _, v := range tests {
+		j := New(v.data)
+		assert.Equal(t, j.MustNumber(), v.number, v.number)
+		assert.Equal(t, j.MustDecimal(), v.decimal, v.number)
+	}
+
+	for _, v := range []interface{}{"1", true, nil, json.Number("x"), json.Number(""), json.Number("1 "), json.Number("01"),
+		json.Number("1e"), math.NaN(), math.Inf(1), []interface{}{1}} {
+		_, err := New(v).Number()
+		assert.NotNil(t, err, v)
+		_, err = New(v).Decimal()
+		assert.NotNil(t, err, v)
+	}
+
+	_, err := New(json.Number("1e10001")).Decimal()
+	assert.NotNil(t, err)
+	assert.Equal(t, New(json.Number("1e10001")).MustNumber(), "1e10001")
+
+	j := New("1.5e3")
+	j.SetWeakType(true)
+	assert.Equal(t, j.MustDecimal(), "1500")
+
+	assert.Equal(t, New("x").MustNumber("0"), "0")
+	assert.Equal(t, New("x").MustDecimal("0"), "0")
+	assert.Panic(t, func() { New("x").MustNumber() })
+	assert.Panic(t, func() { New("x").MustDecimal() })
+	assert.Panic(t, func() { New(1).MustNumber("0", "1") })
+	assert.Panic(t, func() { New(1).MustDecimal("0", "1") })
+}
+
+func Test_BigNumber(t *testing.T) {
+	j, err := Loads(`{"id": 340282366920938463463374607431768211457, "amount": 0.10, "exp": 1.5e3, "neg": -7}`)
+	assert.Nil(t, err)
+
+	n, _ := new(big.Int).SetString("340282366920938463463374607431768211457", 10)
+	assert.Equal(t, j.Get("id").MustBigInt().Cmp(n), 0)
+	assert.Equal(t, j.Get("exp").MustBigInt().Int64(), int64(1500))
+	assert.Equal(t, j.Get("neg").MustBigInt().Int64(), int64(-7))
+	_, err = j.Get("amount").BigInt()
+	assert.NotNil(t, err)
+
+	assert.Equal(t, j.Get("amount").MustBigRat().Cmp(big.NewRat(1, 10)), 0)
+	assert.Equal(t, j.Get("id").MustBigRat().Num().Cmp(n), 0)
+	sum := new(big.Rat).Add(j.Get("amount").MustBigRat(), big.NewRat(2, 10))
+	assert.Equal(t, sum.FloatString(2), "0.30")
+
+	f := j.Get("id").MustBigFloat()
+	i, accuracy := f.Int(nil)
+	assert.Equal(t, i.Cmp(n), 0)
+	assert.Equal(t, accuracy, big.Exact)
+	assert.Equal(t, j.Get("amount").MustBigFloat().Text('g', 10), "0.1")
+	assert.Equal(t, New(json.Number("1e400")).MustBigFloat().Text('g', 10), "1e+400")
+
+	for _, v := range []string{"BigInt", "BigFloat", "BigRat"} {
+		var err error
+		switch v {
+		case "BigInt":
+			_, err = New("x").BigInt()
+		case "BigFloat":
+			_, err = New("x").BigFloat()
+		case "BigRat":
+			_, err = New("x").BigRat()
+		}
+		assert.NotNil(t, err, v)
+	}
+
+	assert.Equal(t, New("x").MustBigInt(big.NewInt(1)).Int64(), int64(1))
+	assert.Equal(t, New("x").MustBigFloat(big.NewFloat(1)).String(), "1")
+	assert.Equal(t, New("x").MustBigRat(big.NewRat(1, 2)).String(), "1/2")
+	assert.Panic(t, func() { New("x").MustBigInt() })
+	assert.Panic(t, func() { New("x").MustBigFloat() })
+	assert.Panic(t, func() { New("x").MustBigRat() })
+	assert.Panic(t, func() { New(1).MustBigInt(nil, nil) })
+	assert.Panic(t, func() { New(1).MustBigFloat(nil, nil) })
+	assert.Panic(t, func() { New(1).MustBigRat(nil, nil) })
+}
+
+func Test_Number_RoundTrip(t *testing.T) {
+	numbers := []string{
+		"0", "-0", "0.10", "1.500", "-0.0", "1e2", "1E+2", "1.0e-10", "12345678901234567890",
+		"340282366920938463463374607431768211457", "0.1000000000000000000000000000000000000001",
+		"1e400", "-1e-400",
+	}
+
+	for _, v := range numbers {
+		text := `{"b":[` + v + `],"a":` + v + `}`
+		expect := `{"a":` + v + `,"b":[` + v + `]}`
+
+		j, err := Loads(text)
+		assert.Nil(t, err, v)
+		s, err := j.Dumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, expect, v)
+		assert.Equal(t, j.Get("a").MustNumber(), v, v)
+
+		s, err = j.PrettyDumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, "{\n    \"a\": "+v+",\n    \"b\": [\n        "+v+"\n    ]\n}", v)
+
+		j = New()
+		j.SetOrdered(true)
+		err = j.Loads(text)
+		assert.Nil(t, err, v)
+		s, err = j.Dumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, text, v)
+
+		j = New()
+		j.SetNonFinite(NonFiniteNull)
+		err = j.Loads(text)
+		assert.Nil(t, err, v)
+		s, err = j.Dumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, expect, v)
+
+		j = New()
+		j.SetPreserve(true)
+		err = j.Loads(text)
+		assert.Nil(t, err, v)
+		j.Set("c", json.Number(v))
+		s, err = j.DumpsSource()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, `{"b":[`+v+`],"a":`+v+`,"c":`+v+`}`, v)
+
+		j, err = LoadsJSON5(text)
+		assert.Nil(t, err, v)
+		s, err = j.DumpsJSON5()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, `{a:`+v+`,b:[`+v+`]}`, v)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Object is the json object which keeps the order of keys
+//   o := NewObject()
+//   o.Set("name", "Li Kexian")
+//   o.Set("link", "https://www.likexian.com/")
+//   New(o).Dumps() // {"name":"Li Kexian","link":"https://www.likexian.com/"}
+type Object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewObject returns a pointer to a new empty ordered json object
+func NewObject() *Object {
+	return &Object{
+		values: map[string]interface{}{},
+	}
+}
+
+// Keys returns the keys of object in order
+func (o *Object) Keys() []string {
+	return append([]string{}, o.keys...)
+}
+
+// Get returns the value of key, and whether it exists
+func (o *Object) Get(key string) (interface{}, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// Set set key-value to object, new key is added at the end, existing key keeps its position
+func (o *Object) Set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// Del delete key-value from object
+func (o *Object) Del(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Len returns the number of keys of object
+func (o *Object) Len() int {
+	return len(o.values)
+}
+
+// Map returns a copy of the key-values of object, changes to it are not reflected to object
+func (o *Object) Map() map[string]interface{} {
+	result := make(map[string]interface{}, len(o.values))
+	for k, v := range o.values {
+		result[k] = v
+	}
+
+	return result
+}
+
+// MarshalJSON returns the json of object with keys in order, implements json.Marshaler
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, k := range o.Keys() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		err := enc.Encode(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+		buf.WriteByte(':')
+		err = enc.Encode(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// SetOrdered set ordered mode for loading and setting, it is off by default
+// when it is on, objects are loaded as *Object keeping the order in source,
+// objects created by Set are *Object, and the maps in json object are converted
+// to *Object with keys sorted, so the order is kept through Set, Del, Keys and Dumps
+//   json := New()
+//   json.SetOrdered(true)
+//   json.Loads(`{"b": 1, "a": 2}`)
+//   json.Set("c", 3)
+//   json.Keys()  // [b a c]
+//   json.Dumps() // {"b":1,"a":2,"c":3}
+func (j *Json) SetOrdered(ordered bool) {
+	j.ordered = ordered
+	j.data = orderData(j.data, ordered)
+}
+
+// Keys returns the keys of json object, in order if it is *Object, otherwise sorted
+func (j *Json) Keys() []string {
+	switch v := j.data.(type) {
+	case *Object:
+		return v.Keys()
+	case map[string]interface{}:
+		result := make([]string, 0, len(v))
+		for k := range v {
+			result = append(result, k)
+		}
+		sort.Strings(result)
+		return result
+	default:
+		return nil
+	}
+}
+
+// newObject returns a new empty object, *Object in ordered mode
+func (j *Json) newObject() interface{} {
+	if j.ordered {
+		return NewObject()
+	}
+
+	return map[string]interface{}{}
+}
+
+// orderData returns data with the maps converted to *Object if ordered, or the reverse
+func orderData(data interface{}, ordered bool) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if !ordered {
+			for k, vv := range v {
+				v[k] = orderData(vv, ordered)
+			}
+			return v
+		}
+		o := NewObject()
+		for k := range v {
+			o.keys = append(o.keys, k)
+		}
+		sort.Strings(o.keys)
+		for _, k := range o.keys {
+			o.values[k] = orderData(v[k], ordered)
+		}
+		return o
+	case *Object:
+		for k, vv := range v.values {
+			v.values[k] = orderData(vv, ordered)
+		}
+		if !ordered {
+			return v.values
+		}
+		return v
+	case []interface{}:
+		for i, vv := range v {
+			v[i] = orderData(vv, ordered)
+		}
+		return v
+	default:
+		return data
+	}
+}
+
+// objectGet returns the value of key in map or *Object, and whether it exists
+func objectGet(data interface{}, key string) (interface{}, bool) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		r, ok := v[key]
+		return r, ok
+	case *Object:
+		return v.Get(key)
+	default:
+		return nil, false
+	}
+}
+
+// objectSet set key-value to map or *Object, returns false if data is not an object
+func objectSet(data interface{}, key string, value interface{}) bool {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		v[key] = value
+		return true
+	case *Object:
+		v.Set(key, value)
+		return true
+	default:
+		return false
+	}
+}
+
+// objectDel delete key from map or *Object, returns whether it exists
+func objectDel(data interface{}, key string) bool {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		_, ok := v[key]
+		delete(v, key)
+		return ok
+	case *Object:
+		_, ok := v.values[key]
+		v.Del(key)
+		return ok
+	default:
+		return false
+	}
+}
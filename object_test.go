@@ -0,0 +1,147 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Object(t *testing.T) {
+	o := NewObject()
+	o.Set("b", 1)
+	o.Set("a", "<a>")
+	o.Set("c", []interface{}{1, NewObject()})
+	o.Set("b", 2)
+	assert.Equal(t, o.Keys(), []string{"b", "a", "c"})
+	assert.Equal(t, o.Len(), 3)
+
+	v, ok := o.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, v, 2)
+	_, ok = o.Get("x")
+	assert.False(t, ok)
+
+	b, err := json.Marshal(o)
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), `{"b":2,"a":"\u003ca\u003e","c":[1,{}]}`)
+
+	o.Del("a")
+	o.Del("x")
+	o.Set("z", 3)
+	o.Set("y", 4)
+	assert.Equal(t, o.Keys(), []string{"b", "c", "z", "y"})
+
+	// Map and Keys are copies
+	m := o.Map()
+	assert.Equal(t, len(m), 4)
+	delete(m, "b")
+	m["x"] = 5
+	o.Keys()[0] = "x"
+	o.Set("b", 3)
+	assert.Equal(t, o.Keys(), []string{"b", "c", "z", "y"})
+
+	s, err := New(o).Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"b":3,"c":[1,{}],"z":3,"y":4}`)
+
+	o.Set("c", make(chan int))
+	_, err = New(o).Dumps()
+	assert.NotNil(t, err)
+}
+
+func Test_Ordered(t *testing.T) {
+	text := `{"z": 1, "a": {"y": true, "b": null}, "m": [{"k": "<", "c": 2}]}`
+
+	j := New()
+	j.SetOrdered(true)
+	err := j.Loads(text)
+	assert.Nil(t, err)
+
+	assert.True(t, j.IsMap())
+	assert.Equal(t, j.Len(), 3)
+	assert.Equal(t, j.Keys(), []string{"z", "a", "m"})
+	assert.Equal(t, j.Get("a").Keys(), []string{"y", "b"})
+	assert.Equal(t, j.Get("m.0").Keys(), []string{"k", "c"})
+	assert.True(t, j.Has("a.b"))
+	assert.Equal(t, j.Get("m.0.c").MustInt(), 2)
+	assert.Equal(t, len(j.Get("a").MustMap()), 2)
+	delete(j.Get("a").MustMap(), "y")
+	assert.Equal(t, j.Get("a").Keys(), []string{"y", "b"})
+	assert.True(t, j.Get("z").Keys() == nil)
+
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"z":1,"a":{"y":true,"b":null},"m":[{"k":"<","c":2}]}`)
+
+	j.SetHtmlEscape(true)
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"z":1,"a":{"y":true,"b":null},"m":[{"k":"\u003c","c":2}]}`)
+	j.SetHtmlEscape(false)
+
+	j.Set("a.x", 1)
+	j.Set("new.d", 1)
+	j.Set("new.c", 2)
+	j.Set("z", 0)
+	j.Del("a.y")
+	j.Get("m").Index(0).Set("b", 3)
+	j.Get("m").Index(0).Del("k")
+	assert.Equal(t, j.Keys(), []string{"z", "a", "m", "new"})
+
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"z":0,"a":{"b":null,"x":1},"m":[{"c":2,"b":3}],"new":{"d":1,"c":2}}`)
+
+	s, err = j.PrettyDumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "{\n    \"z\": 0,\n    \"a\": {\n        \"b\": null,\n        \"x\": 1\n    },\n"+
+		"    \"m\": [\n        {\n            \"c\": 2,\n            \"b\": 3\n        }\n    ],\n"+
+		"    \"new\": {\n        \"d\": 1,\n        \"c\": 2\n    }\n}")
+
+	s, err = j.DumpsJSON5()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{z:0,a:{b:null,x:1},m:[{c:2,b:3}],new:{d:1,c:2}}`)
+
+	j.SetOrdered(false)
+	assert.Equal(t, j.Keys(), []string{"a", "m", "new", "z"})
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":{"b":null,"x":1},"m":[{"b":3,"c":2}],"new":{"c":2,"d":1},"z":0}`)
+	_, ok := j.Get("new").data.(map[string]interface{})
+	assert.True(t, ok)
+
+	j = New()
+	j.SetOrdered(true)
+	j.Set("b", 1)
+	j.Set("a", 2)
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"b":1,"a":2}`)
+
+	j = New(map[string]interface{}{"b": 1, "a": []interface{}{map[string]interface{}{"d": 1, "c": 2}}})
+	j.SetOrdered(true)
+	j.Set("0", 0)
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":[{"c":2,"d":1}],"b":1,"0":0}`)
+}
@@ -0,0 +1,870 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// parser is the json parser for the features beyond encoding/json,
+// it returns the same tree as Loads, map[string]interface{}, []interface{} and json.Number
+type parser struct {
+	data []byte
+	pos  int
+	// partial allows the data to be truncated, the open values are closed at the end
+	partial bool
+	// incomplete is set if the data is truncated in partial mode
+	incomplete bool
+	// trailing allows data after the top-level value
+	trailing bool
+	// comments allows the comments of // and /* */
+	comments bool
+	// trailingComma allows the comma after the last element of array and object
+	trailingComma bool
+	// json5 allows the syntax of JSON5, comments and trailingComma should be set too
+	json5 bool
+	// ordered returns objects as *Object, otherwise map[string]interface{}
+	ordered bool
+	// duplicate is the duplicate key policy, 0 if not set
+	duplicate DuplicateKey
+	// duplicates is the paths of duplicate keys if duplicate is set
+	duplicates []string
+	// collected is the keys of values collected into array by DuplicateArray of each object
+	collected map[*Object]map[string]bool
+	// limits is the limits of data, zero is no limit
+	limits Limits
+	// invalid is the policy of invalid UTF-8 and lone surrogates, 0 if not set
+	invalid InvalidUTF8
+	// nonFinite allows the literals of NaN, Infinity and -Infinity as float64
+	nonFinite bool
+	// depth is the nesting depth of current value
+	depth int
+	// positions records the source position of values by path if not nil
+	positions map[string]Position
+	// lines is the offsets of line starts for positions
+	lines []int
+	// last is the position of last mark, the column is counted from it in the same line
+	last Position
+	// spans records the source span of values by path if not nil
+	spans map[string]span
+}
+
+// span is the source span of json value
+type span struct {
+	// key is the offset of key if the value is an object member, or -1
+	key int
+	// keyEnd is the offset after key if the value is an object member
+	keyEnd int
+	// start is the offset of value
+	start int
+	// end is the offset after value
+	end int
+	// prev is the path of previous member if the value is an object member
+	prev string
+	// last is the path of last member if the value is a non-empty object
+	last string
+}
+
+// partialMissing is the value returned by parser if the data is end before a value in partial mode
+var partialMissing = &struct{}{}
+
+// LoadsPartial unmarshal json from a possibly truncated string, returns json object
+// open strings, arrays and objects are closed, and incomplete keys are dropped,
+// complete is false if the string is truncated
+//   LoadsPartial(`{"name": "Li Ke`) // {"name": "Li Ke"}, false
+func LoadsPartial(text string) (*Json, bool, error) {
+	j := New()
+	complete, err := j.LoadsPartial(text)
+	return j, complete, err
+}
+
+// LoadsPartial unmarshal json from a possibly truncated string, returns whether it is complete
+// the settings of json object are applied as Loads, except that data after the value is an error,
+// and the source is not kept in preserve mode
+func (j *Json) LoadsPartial(text string) (complete bool, err error) {
+	j.positions = nil
+	j.source = nil
+	j.duplicates = nil
+
+	p := j.newParser([]byte(text), false)
+	p.partial = true
+	p.trailing = false
+
+	err = j.load(p)
+	if err != nil {
+		return false, err
+	}
+
+	return !p.incomplete, nil
+}
+
+// parse parses data by parser with the settings of json object, as JSON5 if json5 is true
+func (j *Json) parse(data []byte, json5 bool) error {
+	return j.load(j.newParser(data, json5))
+}
+
+// newParser returns a parser of data with the settings of json object, as JSON5 if json5 is true
+func (j *Json) newParser(data []byte, json5 bool) *parser {
+	return &parser{
+		data:          data,
+		trailing:      !j.strict,
+		comments:      j.jsonc || json5,
+		trailingComma: j.jsonc || json5,
+		json5:         json5,
+		ordered:       j.ordered,
+		duplicate:     j.duplicate,
+		limits:        j.limits,
+		invalid:       j.invalidUTF8,
+		nonFinite:     j.nonFinite == NonFiniteLiteral,
+	}
+}
+
+// load parses the data of parser, and sets the result to json object
+func (j *Json) load(p *parser) error {
+	data := p.data
+	if j.limits.MaxSize > 0 && len(data) > j.limits.MaxSize {
+		return p.limitf(j.limits.MaxSize, "exceeded max size of %d", j.limits.MaxSize)
+	}
+
+	if j.position {
+		p.positions = map[string]Position{}
+	}
+
+	result, err := p.parse()
+	if err != nil {
+		return err
+	}
+
+	j.data = result
+	j.duplicates = p.duplicates
+	if p.positions != nil {
+		j.positions = &positions{values: p.positions}
+	}
+
+	if j.preserve && !p.partial {
+		j.source = &source{
+			data:  append([]byte{}, data...),
+			jsonc: p.comments,
+			json5: p.json5,
+		}
+	}
+
+	return nil
+}
+
+// parse parses the whole data as one json value
+func (p *parser) parse() (interface{}, error) {
+	p.skipSpace()
+	result, err := p.value("")
+	if err != nil {
+		return nil, err
+	}
+
+	if result == partialMissing {
+		return nil, nil
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.data) && !p.trailing {
+		return nil, p.errorf("invalid character %s after top-level value", quoteChar(p.data[p.pos]))
+	}
+
+	return result, nil
+}
+
+// value parses a json value at path, in partial mode it returns partialMissing if the data is end
+func (p *parser) value(path string) (interface{}, error) {
+	if p.pos >= len(p.data) {
+		return partialMissing, p.eof()
+	}
+
+	if p.positions != nil {
+		p.mark(path)
+	}
+
+	if p.spans == nil {
+		return p.next(path)
+	}
+
+	start := p.pos
+	result, err := p.next(path)
+	if err == nil {
+		s, ok := p.spans[path]
+		if !ok {
+			s.key = -1
+		}
+		s.start, s.end = start, p.pos
+		p.spans[path] = s
+	}
+
+	return result, err
+}
+
+// next parses the next json value at path
+func (p *parser) next(path string) (interface{}, error) {
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object(path)
+	case c == '[':
+		return p.array(path)
+	case c == '"' || c == '\'' && p.json5:
+		return p.string()
+	case c == '-' && p.nonFinite && !p.json5 && p.pos+1 < len(p.data) && p.data[p.pos+1] == 'I':
+		return p.literal("-Infinity", math.Inf(-1))
+	case c == '-' || c >= '0' && c <= '9':
+		if p.json5 {
+			return p.number5()
+		}
+		return p.number()
+	case p.json5 && (c == '+' || c == '.' || c == 'I' || c == 'N'):
+		return p.number5()
+	case p.nonFinite && c == 'I':
+		return p.literal("Infinity", math.Inf(1))
+	case p.nonFinite && c == 'N':
+		return p.literal("NaN", math.NaN())
+	case c == 't':
+		return p.literal("true", true)
+	case c == 'f':
+		return p.literal("false", false)
+	case c == 'n':
+		return p.literal("null", nil)
+	default:
+		return nil, p.errorf("invalid character %s looking for beginning of value", quoteChar(c))
+	}
+}
+
+// object parses a json object at path
+func (p *parser) object(path string) (interface{}, error) {
+	result := NewObject()
+	last := ""
+
+	err := p.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	p.pos++
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == '}' {
+		p.pos++
+		return p.objectValue(result), nil
+	}
+
+	for {
+		if p.pos >= len(p.data) {
+			return p.objectValue(result), p.eof()
+		}
+
+		if p.data[p.pos] == '}' && p.trailingComma && result.Len() > 0 {
+			p.pos++
+			p.closeObject(path, last)
+			return p.objectValue(result), nil
+		}
+
+		if p.data[p.pos] != '"' && !p.json5 {
+			return nil, p.errorf("invalid character %s looking for beginning of object key string", quoteChar(p.data[p.pos]))
+		}
+
+		if p.limits.MaxObjectLength > 0 && result.Len() >= p.limits.MaxObjectLength {
+			return nil, p.limitf(p.pos, "exceeded max object length of %d", p.limits.MaxObjectLength)
+		}
+
+		keyStart := p.pos
+		key, err := p.key()
+		if err != nil || p.incomplete {
+			return p.objectValue(result), err
+		}
+
+		keyEnd := p.pos
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return p.objectValue(result), p.eof()
+		}
+		if p.data[p.pos] != ':' {
+			return nil, p.errorf("invalid character %s after object key", quoteChar(p.data[p.pos]))
+		}
+
+		child := p.childPath(path, key.(string))
+		if _, ok := result.values[key.(string)]; ok && p.duplicate == DuplicateError {
+			e := newSyntaxError(p.data, keyStart, "duplicate key "+child)
+			e.Err = ErrDuplicateKey
+			return nil, e
+		}
+
+		p.pos++
+		p.skipSpace()
+		value, err := p.value(child)
+		if err != nil {
+			return nil, err
+		}
+		if p.spans != nil && !p.incomplete {
+			s := p.spans[child]
+			s.key, s.keyEnd, s.prev = keyStart, keyEnd, last
+			p.spans[child] = s
+			last = child
+		}
+		if p.incomplete {
+			if value != partialMissing {
+				p.member(result, child, key.(string), value)
+			}
+			return p.objectValue(result), nil
+		}
+
+		p.member(result, child, key.(string), value)
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return p.objectValue(result), p.eof()
+		}
+
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+			p.skipSpace()
+		case '}':
+			p.pos++
+			p.closeObject(path, last)
+			return p.objectValue(result), nil
+		default:
+			return nil, p.errorf("invalid character %s after object key:value pair", quoteChar(p.data[p.pos]))
+		}
+	}
+}
+
+// objectValue returns the object as *Object in ordered mode, otherwise its map
+func (p *parser) objectValue(o *Object) interface{} {
+	if p.ordered {
+		return o
+	}
+
+	return o.values
+}
+
+// array parses a json array at path
+func (p *parser) array(path string) (interface{}, error) {
+	result := []interface{}{}
+
+	err := p.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	p.pos++
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == ']' {
+		p.pos++
+		return result, nil
+	}
+
+	for {
+		if p.pos < len(p.data) && p.data[p.pos] == ']' && p.trailingComma && len(result) > 0 {
+			p.pos++
+			return result, nil
+		}
+
+		if p.limits.MaxArrayLength > 0 && len(result) >= p.limits.MaxArrayLength {
+			return nil, p.limitf(p.pos, "exceeded max array length of %d", p.limits.MaxArrayLength)
+		}
+
+		value, err := p.value(p.childPath(path, strconv.Itoa(len(result))))
+		if err != nil {
+			return nil, err
+		}
+		if p.incomplete {
+			if value != partialMissing {
+				result = append(result, value)
+			}
+			return result, nil
+		}
+
+		result = append(result, value)
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return result, p.eof()
+		}
+
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+			p.skipSpace()
+		case ']':
+			p.pos++
+			return result, nil
+		default:
+			return nil, p.errorf("invalid character %s after array element", quoteChar(p.data[p.pos]))
+		}
+	}
+}
+
+// key parses a json object key
+func (p *parser) key() (interface{}, error) {
+	if p.json5 {
+		return p.key5()
+	}
+
+	return p.string()
+}
+
+// string parses a json string, or a single quoted string in JSON5 mode
+func (p *parser) string() (interface{}, error) {
+	start := p.pos
+	quote := p.data[p.pos]
+	p.pos++
+
+	buf := make([]byte, 0, 16)
+	for {
+		err := p.checkString(start, buf)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.pos >= len(p.data) {
+			return string(buf), p.eof()
+		}
+
+		c := p.data[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return string(buf), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.data) {
+				p.pos = len(p.data)
+				return string(buf), p.eof()
+			}
+			e := p.data[p.pos+1]
+			switch e {
+			case '"', '\\', '/':
+				buf = append(buf, e)
+			case 'b':
+				buf = append(buf, '\b')
+			case 'f':
+				buf = append(buf, '\f')
+			case 'n':
+				buf = append(buf, '\n')
+			case 'r':
+				buf = append(buf, '\r')
+			case 't':
+				buf = append(buf, '\t')
+			case 'u':
+				r, n, err := p.unicode()
+				if err != nil || p.incomplete {
+					return string(buf), err
+				}
+				buf = appendRune(buf, r)
+				p.pos += n
+				continue
+			default:
+				if p.json5 {
+					var err error
+					buf, err = p.escape5(buf)
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+				p.pos++
+				return nil, p.errorf("invalid character %s in string escape code", quoteChar(e))
+			}
+			p.pos += 2
+		case c < 0x20 && (!p.json5 || c == '\n' || c == '\r'):
+			return nil, p.errorf("invalid character %s in string literal", quoteChar(c))
+		case c < utf8.RuneSelf:
+			buf = append(buf, c)
+			p.pos++
+		default:
+			r, n := utf8.DecodeRune(p.data[p.pos:])
+			if r == utf8.RuneError && n == 1 && p.partial && !utf8.FullRune(p.data[p.pos:]) {
+				p.pos = len(p.data)
+				return string(buf), p.eof()
+			}
+			if r == utf8.RuneError && n == 1 && p.invalid == InvalidUTF8Error {
+				return nil, p.errorf("invalid UTF-8 byte '\\x%02x' in string literal", c)
+			}
+			if r == utf8.RuneError && n == 1 && p.invalid == InvalidUTF8Preserve {
+				buf = append(buf, c)
+				p.pos++
+				continue
+			}
+			buf = append(buf, string(r)...)
+			p.pos += n
+		}
+	}
+}
+
+// unicode parses the \uXXXX escape at pos, with the following low surrogate if any,
+// returns the rune and the length of escape
+func (p *parser) unicode() (rune, int, error) {
+	r, err := p.hex4(p.pos + 2)
+	if err != nil || p.incomplete {
+		return 0, 0, err
+	}
+
+	if !utf16.IsSurrogate(r) {
+		return r, 6, nil
+	}
+
+	i := p.pos + 6
+	if i+1 < len(p.data) && p.data[i] == '\\' && p.data[i+1] == 'u' {
+		r2, err := p.hex4(i + 2)
+		if err != nil || p.incomplete {
+			return 0, 0, err
+		}
+		if d := utf16.DecodeRune(r, r2); d != utf8.RuneError {
+			return d, 12, nil
+		}
+	} else if i >= len(p.data)-1 && p.partial {
+		p.pos = len(p.data)
+		return 0, 0, p.eof()
+	}
+
+	return p.surrogate(r)
+}
+
+// hex4 parses 4 hex digits at i
+func (p *parser) hex4(i int) (rune, error) {
+	if i+4 > len(p.data) {
+		for k := i; k < len(p.data); k++ {
+			if !isHex(p.data[k]) {
+				p.pos = k
+				return 0, p.errorf("invalid character %s in \\u hexadecimal character escape", quoteChar(p.data[k]))
+			}
+		}
+		p.pos = len(p.data)
+		return 0, p.eof()
+	}
+
+	for k := i; k < i+4; k++ {
+		if !isHex(p.data[k]) {
+			p.pos = k
+			return 0, p.errorf("invalid character %s in \\u hexadecimal character escape", quoteChar(p.data[k]))
+		}
+	}
+
+	r, _ := strconv.ParseUint(string(p.data[i:i+4]), 16, 32)
+
+	return rune(r), nil
+}
+
+// number parses a json number, returns json.Number
+func (p *parser) number() (interface{}, error) {
+	start := p.pos
+	valid := p.pos
+
+	if p.data[p.pos] == '-' {
+		p.pos++
+	}
+
+	if p.pos >= len(p.data) {
+		return p.numberEnd(start, valid)
+	}
+
+	if p.data[p.pos] == '0' {
+		p.pos++
+	} else if p.data[p.pos] >= '1' && p.data[p.pos] <= '9' {
+		p.skipDigits()
+	} else {
+		return nil, p.errorf("invalid character %s in numeric literal", quoteChar(p.data[p.pos]))
+	}
+	valid = p.pos
+
+	if p.pos < len(p.data) && p.data[p.pos] == '.' {
+		p.pos++
+		if p.pos >= len(p.data) {
+			return p.numberEnd(start, valid)
+		}
+		if !isDigit(p.data[p.pos]) {
+			return nil, p.errorf("invalid character %s after decimal point in numeric literal", quoteChar(p.data[p.pos]))
+		}
+		p.skipDigits()
+		valid = p.pos
+	}
+
+	if p.pos < len(p.data) && (p.data[p.pos] == 'e' || p.data[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+			p.pos++
+		}
+		if p.pos >= len(p.data) {
+			return p.numberEnd(start, valid)
+		}
+		if !isDigit(p.data[p.pos]) {
+			return nil, p.errorf("invalid character %s in exponent of numeric literal", quoteChar(p.data[p.pos]))
+		}
+		p.skipDigits()
+		valid = p.pos
+	}
+
+	err := p.checkNumber(start)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Number(p.data[start:p.pos]), nil
+}
+
+// numberEnd returns the valid part of a truncated number
+func (p *parser) numberEnd(start, valid int) (interface{}, error) {
+	err := p.eof()
+	if err != nil {
+		return nil, err
+	}
+
+	if valid == start {
+		return partialMissing, nil
+	}
+
+	return json.Number(p.data[start:valid]), nil
+}
+
+// literal parses the literal name, returns value
+func (p *parser) literal(name string, value interface{}) (interface{}, error) {
+	for i := 0; i < len(name); i++ {
+		if p.pos >= len(p.data) {
+			return value, p.eof()
+		}
+		if p.data[p.pos] != name[i] {
+			return nil, p.errorf("invalid character %s in literal %s (expecting %s)",
+				quoteChar(p.data[p.pos]), name, quoteChar(name[i]))
+		}
+		p.pos++
+	}
+
+	return value, nil
+}
+
+// member set key-value at path to object by the duplicate key policy
+func (p *parser) member(o *Object, path, key string, value interface{}) {
+	old, ok := o.values[key]
+	if !ok || p.duplicate == 0 {
+		o.Set(key, value)
+		return
+	}
+
+	p.duplicates = append(p.duplicates, path)
+
+	switch p.duplicate {
+	case DuplicateFirst:
+	case DuplicateArray:
+		if p.collected == nil {
+			p.collected = map[*Object]map[string]bool{}
+		}
+		if p.collected[o] == nil {
+			p.collected[o] = map[string]bool{}
+		}
+		if values, ok := old.([]interface{}); ok && p.collected[o][key] {
+			o.Set(key, append(values, value))
+		} else {
+			o.Set(key, []interface{}{old, value})
+			p.collected[o][key] = true
+		}
+	default:
+		o.Set(key, value)
+	}
+}
+
+// closeObject records the last member of object at path
+func (p *parser) closeObject(path, last string) {
+	if p.spans != nil {
+		s, ok := p.spans[path]
+		if !ok {
+			s.key = -1
+		}
+		s.last = last
+		p.spans[path] = s
+	}
+}
+
+// childPath returns the path of child at key, only if positions or spans is recorded
+func (p *parser) childPath(path, key string) string {
+	if p.positions == nil && p.spans == nil && p.duplicate == 0 {
+		return ""
+	}
+
+	return joinPath(path, key)
+}
+
+// mark records the source position at pos as the position of path
+func (p *parser) mark(path string) {
+	if p.lines == nil {
+		p.lines = []int{0}
+		for i, c := range p.data {
+			if c == '\n' {
+				p.lines = append(p.lines, i+1)
+			}
+		}
+	}
+
+	line := sort.SearchInts(p.lines, p.pos+1)
+	start, column := p.lines[line-1], 1
+	if p.last.Line == line && p.last.Offset <= int64(p.pos) {
+		start, column = int(p.last.Offset), p.last.Column
+	}
+
+	p.last = Position{
+		Line:   line,
+		Column: column + utf8.RuneCount(p.data[start:p.pos]),
+		Offset: int64(p.pos),
+	}
+	p.positions[path] = p.last
+}
+
+// skipSpace skips the whitespace, and the comments if allowed
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '/':
+			n := commentLen(p.data[p.pos:])
+			if !p.comments || n == 0 {
+				return
+			}
+			p.pos += n
+		default:
+			if !p.json5 || !p.space5() {
+				return
+			}
+		}
+	}
+}
+
+// skipDigits skips the digits
+func (p *parser) skipDigits() {
+	for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+		p.pos++
+	}
+}
+
+// enter enters a nested array or object, returns the error if the depth exceeds the limit,
+// the depth is never more than maxDepth even if there is no limit
+func (p *parser) enter() error {
+	p.depth++
+
+	limit := p.limits.MaxDepth
+	if limit <= 0 || limit > maxDepth {
+		limit = maxDepth
+	}
+
+	if p.depth > limit {
+		return p.limitf(p.pos, "exceeded max depth of %d", limit)
+	}
+
+	return nil
+}
+
+// leave leaves a nested array or object
+func (p *parser) leave() {
+	p.depth--
+}
+
+// checkString returns the error if the unescaped string buf from start exceeds the limit,
+// it is checked while parsing so the string is never buffered more than the limit
+func (p *parser) checkString(start int, buf []byte) error {
+	if p.limits.MaxStringLength > 0 && len(buf) > p.limits.MaxStringLength {
+		return p.limitf(start, "exceeded max string length of %d", p.limits.MaxStringLength)
+	}
+
+	return nil
+}
+
+// checkNumber returns the error if the number literal from start to pos exceeds the limit
+func (p *parser) checkNumber(start int) error {
+	if p.limits.MaxNumberLength > 0 && p.pos-start > p.limits.MaxNumberLength {
+		return p.limitf(start, "exceeded max number length of %d", p.limits.MaxNumberLength)
+	}
+
+	return nil
+}
+
+// eof returns the error of unexpected end, in partial mode marks incomplete and returns nil
+func (p *parser) eof() error {
+	if p.partial {
+		p.incomplete = true
+		return nil
+	}
+
+	return p.errorf("unexpected end of JSON input")
+}
+
+// errorf returns the *SyntaxError at pos
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return newSyntaxError(p.data, p.pos, fmt.Sprintf(format, args...))
+}
+
+// commentLen returns the length of comment of // or /* */ at the start of data,
+// or 0 if it is not a comment or the comment is not terminated
+func commentLen(data []byte) int {
+	if bytes.HasPrefix(data, []byte("//")) {
+		n := bytes.IndexByte(data, '\n')
+		if n < 0 {
+			return len(data)
+		}
+		if data[n-1] == '\r' {
+			n--
+		}
+		return n
+	}
+
+	if bytes.HasPrefix(data, []byte("/*")) {
+		n := bytes.Index(data[2:], []byte("*/"))
+		if n < 0 {
+			return 0
+		}
+		return n + 4
+	}
+
+	return 0
+}
+
+// quoteChar returns the quoted character for error message, as encoding/json
+func quoteChar(c byte) string {
+	if c == '\'' {
+		return `'\''`
+	}
+
+	if c == '"' {
+		return `'"'`
+	}
+
+	s := strconv.Quote(string(c))
+
+	return "'" + s[1:len(s)-1] + "'"
+}
+
+// isDigit returns whether c is a decimal digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isHex returns whether c is a hexadecimal digit
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+var textParser = []string{
+	textResult,
+	textStream,
+	`{}`, `[]`, `""`, `0`, `-0.5e+10`, `1E-2`, `true`, `false`, `null`,
+	` { "a" : [ 1 , { "b" : null } ] , "c" : "d" } `,
+	`["\"\\\/\b\f\n\r\t", "中文", "😀", "\ud83d", "\ud83dx", "\ud83dA", "中文"]`,
+	"[\"\xff\", \"a\xe4\xb8\"]",
+	`{"a": 1, "a": 2}`,
+	`[12345678901234567890, 0.10, 1e400]`,
+}
+
+func Test_Parser(t *testing.T) {
+	for _, v := range textParser {
+		expect, err := Loads(v)
+		assert.Nil(t, err, v)
+		es, err := expect.Dumps()
+		assert.Nil(t, err, v)
+
+		p := &parser{data: []byte(v)}
+		data, err := p.parse()
+		assert.Nil(t, err, v)
+		s, err := New(data).Dumps()
+		assert.Nil(t, err, v)
+		assert.Equal(t, s, es, v)
+	}
+
+	invalid := []string{
+		``, ` `, `{`, `[`, `"`, `{"a"`, `{"a":`, `{"a":1`, `{"a":1,`, `[1`, `[1,`, `"\`, `"\u12`,
+		`{,}`, `{"a" 1}`, `{"a":1 "b":2}`, `{"a":1,}`, `[1,]`, `[1 2]`, `[,1]`, `{1:1}`, `{'a':1}`,
+		`'a'`, `+1`, `01`, `1.`, `1.e1`, `.1`, `1e`, `1e+`, `-`, `-a`, `tru`, `trux`, `nul`, `nulL`, `fals`,
+		`"\x"`, `"\u12x4"`, "\"\t\"", `"\ud83d\u12x4"`, `1 2`, `{} {}`, `[]]`, `NaN`, `Infinity`,
+	}
+
+	for _, v := range invalid {
+		var data interface{}
+		assert.NotNil(t, json.Unmarshal([]byte(v), &data), v)
+
+		p := &parser{data: []byte(v)}
+		_, err := p.parse()
+		assert.NotNil(t, err, v)
+	}
+}
+
+func Test_Loads_Partial(t *testing.T) {
+	tests := []struct {
+		text     string
+		expect   string
+		complete bool
+	}{
+		{``, `null`, false},
+		{` `, `null`, false},
+		{`{`, `{}`, false},
+		{`{"na`, `{}`, false},
+		{`{"name"`, `{}`, false},
+		{`{"name":`, `{}`, false},
+		{`{"name": "Li Ke`, `{"name":"Li Ke"}`, false},
+		{`{"name": "Li Kexian"`, `{"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian",`, `{"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian", "age": 1`, `{"age":1,"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian", "age": -`, `{"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian", "age": 1.`, `{"age":1,"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian", "age": 1.5e`, `{"age":1.5,"name":"Li Kexian"}`, false},
+		{`{"name": "Li Kexian", "age": 1.5e-`, `{"age":1.5,"name":"Li Kexian"}`, false},
+		{`{"a": [1, 2`, `{"a":[1,2]}`, false},
+		{`{"a": [1, 2,`, `{"a":[1,2]}`, false},
+		{`{"a": [1, {"b": tr`, `{"a":[1,{"b":true}]}`, false},
+		{`{"a": [1, {"b": n`, `{"a":[1,{"b":null}]}`, false},
+		{`[f`, `[false]`, false},
+		{`[-`, `[]`, false},
+		{`["a\`, `["a"]`, false},
+		{`["a\u4e`, `["a"]`, false},
+		{`["a中`, `["a中"]`, false},
+		{`["a\ud83d`, `["a"]`, false},
+		{`["a\ud83d\`, `["a"]`, false},
+		{`["a\ud83d\ude`, `["a"]`, false},
+		{`["a😀`, `["a😀"]`, false},
+		{"[\"a\xe4\xb8", `["a"]`, false},
+		{`12`, `12`, true},
+		{`"a"`, `"a"`, true},
+		{`{"a": [1, 2]} `, `{"a":[1,2]}`, true},
+	}
+
+	for _, v := range tests {
+		j, complete, err := LoadsPartial(v.text)
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, complete, v.complete, v.text)
+		s, err := j.Dumps()
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, s, v.expect, v.text)
+	}
+
+	// Every prefix is loadable
+	for _, v := range textParser {
+		for i := 0; i < len(v); i++ {
+			_, _, err := LoadsPartial(v[:i])
+			assert.Nil(t, err, v[:i])
+		}
+	}
+
+	// Settings of json object are applied, and the state of last loading is reset
+	j := New()
+	j.SetPreserve(true)
+	j.SetPosition(true)
+	j.SetOrdered(true)
+	j.SetDuplicateKey(DuplicateFirst)
+	err := j.Loads(`{"z": 1}`)
+	assert.Nil(t, err)
+	complete, err := j.LoadsPartial(`{"b": 1, "b": 2, "a": [1, 2`)
+	assert.Nil(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, j.Keys(), []string{"b", "a"})
+	assert.Equal(t, j.Duplicates(), []string{"b"})
+	assert.Equal(t, j.Get("a").Position().String(), "1:23")
+	assert.False(t, j.Get("z").Position().IsValid())
+	s, err := j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "{\n    \"b\": 1,\n    \"a\": [\n        1,\n        2\n    ]\n}")
+
+	j.SetLimits(Limits{MaxArrayLength: 1})
+	_, err = j.LoadsPartial(`[1, 2`)
+	assert.Equal(t, err.(*SyntaxError).Err, ErrLimitExceeded)
+
+	j = New()
+	j.SetJSONC(true)
+	_, err = j.LoadsPartial(`[1, /* c */ 2`)
+	assert.Nil(t, err)
+	assert.Equal(t, j.MustArray(), []interface{}{json.Number("1"), json.Number("2")})
+
+	// Invalid json is error
+	for _, v := range []string{`{"a": x`, `[1 2`, `{"a" 1`, `{1`, `["\x`, `["\u4x`, `["\ud83d\u4x`, "[\"\n", `[1]]`, `[1.x`, `[1ex`, `[-x`, `[tx`, `{"a":1 x`} {
+		_, complete, err := LoadsPartial(v)
+		assert.NotNil(t, err, v)
+		assert.False(t, complete)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Position is the source position of json value
+type Position struct {
+	// File is the file name if loaded by Load
+	File string
+	// Line is the line number, starts from 1
+	Line int
+	// Column is the column number in characters, starts from 1
+	Column int
+	// Offset is the byte offset, starts from 0
+	Offset int64
+}
+
+// positions is the source positions of json values by path
+type positions struct {
+	file   string
+	values map[string]Position
+}
+
+// IsValid returns whether the position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the string of position, as file:line:column
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+
+	result := strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+	if p.File != "" {
+		result = p.File + ":" + result
+	}
+
+	return result
+}
+
+// SetPosition set position tracking for loading, it is off by default
+// when it is on, the source position of every value is recorded while loading,
+// and could be got by Position, the whole data is read before parsing
+//   json := New()
+//   json.SetPosition(true)
+//   json.Load("config.json")
+//   json.Get("db.port").Position() // config.json:3:17
+func (j *Json) SetPosition(track bool) {
+	j.position = track
+}
+
+// Position returns the source position of json object
+// the position is invalid if not tracked or the value is set after loading
+func (j *Json) Position() Position {
+	if j.positions == nil {
+		return Position{}
+	}
+
+	result, ok := j.positions.values[j.path]
+	if !ok {
+		return Position{}
+	}
+
+	result.File = j.positions.file
+
+	return result
+}
+
+// forget removes the positions of path and its children
+func (p *positions) forget(path string) {
+	if p == nil {
+		return
+	}
+
+	for k := range p.values {
+		if path == "" || k == path || strings.HasPrefix(k, path+".") {
+			delete(p.values, k)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+func Test_Position(t *testing.T) {
+	text := "{\n    \"name\": \"中文\", \"db\": {\n\t\"port\": 3306,\n\t\"hosts\": [\"a\", [\"b\"]]\n    }\n}"
+
+	j := New()
+	j.SetPosition(true)
+	err := j.Loads(text)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		path   string
+		expect string
+		offset int64
+	}{
+		{"", "1:1", 0},
+		{"name", "2:13", 14},
+		{"db", "2:25", 30},
+		{"db.port", "3:10", 41},
+		{"db.hosts", "4:11", 57},
+		{"db.hosts.0", "4:12", 58},
+		{"db.hosts.1", "4:17", 63},
+		{"db.hosts.1.0", "4:18", 64},
+	}
+
+	for _, v := range tests {
+		p := j.Get(v.path).Position()
+		assert.True(t, p.IsValid(), v.path)
+		assert.Equal(t, p.String(), v.expect, v.path)
+		assert.Equal(t, p.Offset, v.offset, v.path)
+		assert.Equal(t, text[p.Offset:p.Offset+1] != " ", true, v.path)
+	}
+
+	p := j.Get("db").Get("hosts").Index(1).Position()
+	assert.Equal(t, p.String(), "4:17")
+
+	assert.False(t, j.Get("db.none").Position().IsValid())
+	assert.Equal(t, j.Get("db.none").Position().String(), "-")
+
+	j.Set("db.port", 3307)
+	assert.False(t, j.Get("db.port").Position().IsValid())
+	assert.True(t, j.Get("db.hosts").Position().IsValid())
+
+	j.Del("db")
+	assert.False(t, j.Get("db.hosts.0").Position().IsValid())
+	assert.True(t, j.Get("name").Position().IsValid())
+
+	j = New()
+	err = j.Loads(text)
+	assert.Nil(t, err)
+	assert.False(t, j.Get("name").Position().IsValid())
+
+	j.SetPosition(true)
+	err = j.LoadReader(strings.NewReader(text + " trailing"))
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("db.port").Position().String(), "3:10")
+	assert.Equal(t, j.Get("db.port").MustInt(), 3306)
+
+	j.SetStrict(true)
+	err = j.Loads(text + " trailing")
+	assert.NotNil(t, err)
+	_, ok := err.(*SyntaxError)
+	assert.True(t, ok)
+}
+
+func Test_Position_Minified(t *testing.T) {
+	text := "[" + strings.Repeat(`"中文", {"a": 1}, `, 1000) + "2]"
+
+	j := New()
+	j.SetPosition(true)
+	err := j.Loads(text)
+	assert.Nil(t, err)
+
+	for i := 0; i <= 2000; i++ {
+		p := j.Index(i).Position()
+		assert.Equal(t, p.Line, 1)
+		assert.Equal(t, p.Column, utf8.RuneCountInString(text[:p.Offset])+1)
+	}
+
+	p := j.Get("1999.a").Position()
+	assert.Equal(t, p.Column, utf8.RuneCountInString(text[:p.Offset])+1)
+	assert.Equal(t, text[p.Offset:p.Offset+1], "1")
+}
+
+func Test_Position_Load(t *testing.T) {
+	defer os.Remove("position.json")
+
+	err := xfile.WriteText("position.json", "{\n  \"db\": {\n    \"port\": 3306\n  }\n}\n")
+	assert.Nil(t, err)
+
+	j := New()
+	j.SetPosition(true)
+	err = j.Load("position.json")
+	assert.Nil(t, err)
+
+	p := j.Get("db.port").Position()
+	assert.Equal(t, p.File, "position.json")
+	assert.Equal(t, p.Line, 3)
+	assert.Equal(t, p.Column, 13)
+	assert.Equal(t, p.String(), "position.json:3:13")
+
+	err = xfile.WriteText("position.json", "{\n  \"db\": x\n}\n")
+	assert.Nil(t, err)
+
+	err = j.Load("position.json")
+	assert.NotNil(t, err)
+	assert.Equal(t, err.Error(), "simplejson: position.json:2:9: invalid character 'x' looking for beginning of value")
+}
@@ -0,0 +1,398 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// source is the source text of json object for editing in preserve mode
+type source struct {
+	data  []byte
+	jsonc bool
+	json5 bool
+	err   error
+}
+
+// SetJSONC set JSONC mode for loading, it is off by default
+// when it is on, the comments of // and /* */ and the trailing commas are allowed
+//   json := New()
+//   json.SetJSONC(true)
+//   json.Loads(`{"port": 3306, /* mysql */}`)
+func (j *Json) SetJSONC(jsonc bool) {
+	j.jsonc = jsonc
+}
+
+// SetPreserve set preserve mode for loading and editing, it is off by default
+// when it is on, the source text is kept after loading, Set and Del patch only the affected
+// bytes of the source, and Dump and DumpsSource output the patched source,
+// so comments, whitespace and key order are preserved, new multi-line values follow
+// the indentation and newline style of the source, data changed in other ways is not reflected
+//   json := New()
+//   json.SetJSONC(true)
+//   json.SetPreserve(true)
+//   json.Load("config.jsonc")
+//   json.Set("db.port", 3307)
+//   json.Dump("config.jsonc")
+func (j *Json) SetPreserve(preserve bool) {
+	j.preserve = preserve
+}
+
+// DumpsSource returns the json as Dump writes to file,
+// the edited source in preserve mode, otherwise the same as PrettyDumps
+func (j *Json) DumpsSource() (result string, err error) {
+	data, ok, err := j.edited()
+	if !ok {
+		return j.PrettyDumps()
+	}
+
+	return string(data), err
+}
+
+// edited returns the edited source in preserve mode, ok is false if not in preserve mode
+func (j *Json) edited() (result []byte, ok bool, err error) {
+	if j.source == nil || j.path != "" {
+		return nil, false, nil
+	}
+
+	if j.source.err != nil {
+		return nil, true, j.source.err
+	}
+
+	return append([]byte{}, j.source.data...), true, nil
+}
+
+// editSet patches the source for setting value at key
+func (j *Json) editSet(key string, value interface{}) {
+	if j.source == nil || j.source.err != nil {
+		return
+	}
+
+	c := j.child(value, "")
+	c.source = nil
+	data, err := c.doDumpBytes("")
+	if err != nil {
+		j.source.err = err
+		return
+	}
+
+	j.source.set(joinPath(j.path, key), data)
+}
+
+// editDel patches the source for deleting key
+func (j *Json) editDel(key string) {
+	if j.source == nil || j.source.err != nil {
+		return
+	}
+
+	j.source.del(joinPath(j.path, key))
+}
+
+// spans returns the spans of values in source by path
+func (s *source) spans() map[string]span {
+	p := &parser{
+		data:          s.data,
+		trailing:      true,
+		comments:      s.jsonc,
+		trailingComma: s.jsonc,
+		json5:         s.json5,
+		spans:         map[string]span{},
+	}
+
+	_, err := p.parse()
+	if err != nil {
+		return map[string]span{}
+	}
+
+	return p.spans
+}
+
+// set replaces the value at path, or inserts it as the last member of its nearest object
+func (s *source) set(path string, value []byte) {
+	spans := s.spans()
+	if v, ok := spans[path]; ok {
+		pretty := bytes.IndexByte(s.data[v.start:v.end], '\n') >= 0
+		s.replace(v.start, v.end, s.format(spans, value, s.lineIndent(v.start), pretty))
+		return
+	}
+
+	keys := strings.Split(path, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		v, ok := spans[strings.Join(keys[:i], ".")]
+		if ok {
+			if s.data[v.start] == '{' {
+				s.insert(spans, v, keys[i:], value)
+			}
+			return
+		}
+	}
+}
+
+// insert inserts value at keys as the last member of object,
+// following the separator, colon and indentation of the previous member
+func (s *source) insert(spans map[string]span, object span, keys []string, value []byte) {
+	colon := ": "
+	sep := ""
+	last, ok := spans[object.last]
+	ok = ok && object.last != ""
+	if ok {
+		if v := string(s.data[last.keyEnd:last.start]); strings.Trim(v, " \t:") == "" {
+			colon = v
+		}
+		i := last.key
+		for i > 0 && isSpace(s.data[i-1]) {
+			i--
+		}
+		sep = string(s.data[i:last.key])
+	}
+
+	text := value
+	for i := len(keys) - 1; i > 0; i-- {
+		key, _ := json.Marshal(keys[i])
+		text = []byte("{" + string(key) + colon + string(text) + "}")
+	}
+
+	pretty := bytes.IndexByte(s.data[object.start:object.end], '\n') >= 0
+	if ok {
+		text = s.format(spans, text, s.lineIndent(last.key), pretty)
+	} else if pretty {
+		// the first member of multi-line object is in its own line
+		indent, newline := s.style(spans)
+		prefix := s.lineIndent(object.start) + indent
+		text = s.format(spans, text, prefix, pretty)
+		sep = newline + prefix
+	}
+
+	key, _ := json.Marshal(keys[0])
+	member := string(key) + colon + string(text)
+
+	if !ok {
+		s.replace(object.start+1, object.start+1, []byte(sep+member))
+		return
+	}
+
+	// the member in the same line is inserted before the line comment, not into it
+	inline := strings.IndexByte(sep, '\n') < 0
+
+	next := s.skip(last.end)
+	if next < len(s.data) && s.data[next] == ',' {
+		at, comment := s.lineEnd(next + 1)
+		if comment && inline {
+			at = next + 1
+		}
+		s.replace(at, at, []byte(sep+member+","))
+		return
+	}
+
+	at, comment := s.lineEnd(last.end)
+	if comment && inline {
+		at = last.end
+	}
+	s.replace(at, at, []byte(sep+member))
+	s.replace(last.end, last.end, []byte(","))
+}
+
+// format returns the compact json value formatted in the style of source if pretty,
+// the lines after the first are prefixed with prefix
+func (s *source) format(spans map[string]span, value []byte, prefix string, pretty bool) []byte {
+	if !pretty {
+		return value
+	}
+
+	indent, newline := s.style(spans)
+
+	var buf bytes.Buffer
+	err := json.Indent(&buf, value, prefix, indent)
+	if err != nil {
+		return value
+	}
+
+	return bytes.Replace(buf.Bytes(), []byte("\n"), []byte(newline), -1)
+}
+
+// style returns the indentation and newline of source, 4 spaces and \n if unknown,
+// the indentation is detected by the first object which has its members in their own lines
+func (s *source) style(spans map[string]span) (indent, newline string) {
+	indent, newline = "    ", "\n"
+	if bytes.Contains(s.data, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+
+	found := -1
+	for _, v := range spans {
+		if v.last == "" || found >= 0 && v.start > found {
+			continue
+		}
+		last := spans[v.last].key
+		if !s.lineStart(last) {
+			continue
+		}
+		outer, inner := s.lineIndent(v.start), s.lineIndent(last)
+		if len(inner) > len(outer) && strings.HasPrefix(inner, outer) {
+			indent = inner[len(outer):]
+			found = v.start
+		}
+	}
+
+	return
+}
+
+// lineIndent returns the leading whitespace of the line at i
+func (s *source) lineIndent(i int) string {
+	start := bytes.LastIndexByte(s.data[:i], '\n') + 1
+	end := start
+	for end < len(s.data) && (s.data[end] == ' ' || s.data[end] == '\t') {
+		end++
+	}
+
+	return string(s.data[start:end])
+}
+
+// lineStart returns whether there is only whitespace before i in its line
+func (s *source) lineStart(i int) bool {
+	for i > 0 && (s.data[i-1] == ' ' || s.data[i-1] == '\t') {
+		i--
+	}
+
+	return i == 0 || s.data[i-1] == '\n'
+}
+
+// del deletes the object member at path, with its comma,
+// the whole line is deleted if the member is in its own line,
+// and the object is collapsed to {} if it is the only member
+func (s *source) del(path string) {
+	spans := s.spans()
+	v, ok := spans[path]
+	if !ok || v.key < 0 {
+		return
+	}
+
+	end := v.end
+	next := s.skip(v.end)
+	comma := next < len(s.data) && s.data[next] == ','
+	if comma {
+		end = next + 1
+	}
+
+	open := v.key
+	for open > 0 && isSpace(s.data[open-1]) {
+		open--
+	}
+	close := end
+	for close < len(s.data) && isSpace(s.data[close]) {
+		close++
+	}
+	if open > 0 && s.data[open-1] == '{' && close < len(s.data) && s.data[close] == '}' {
+		s.replace(open, close, nil)
+		return
+	}
+
+	prevComma := -1
+	if !comma && v.prev != "" {
+		prevComma = s.skip(spans[v.prev].end)
+	}
+
+	start := v.key
+	for start > 0 && (s.data[start-1] == ' ' || s.data[start-1] == '\t') {
+		start--
+	}
+
+	after, _ := s.lineEnd(end)
+	for after < len(s.data) && (s.data[after] == ' ' || s.data[after] == '\t' || s.data[after] == '\r') {
+		after++
+	}
+
+	if (start == 0 || s.data[start-1] == '\n') && (after == len(s.data) || s.data[after] == '\n') {
+		if after < len(s.data) {
+			after++
+		}
+		s.replace(start, after, nil)
+		if prevComma >= 0 {
+			s.replace(prevComma, prevComma+1, nil)
+		}
+		return
+	}
+
+	switch {
+	case comma:
+		for end < len(s.data) && isSpace(s.data[end]) {
+			end++
+		}
+		s.replace(v.key, end, nil)
+	case prevComma >= 0:
+		s.replace(prevComma, v.end, nil)
+	default:
+		s.replace(v.key, v.end, nil)
+	}
+}
+
+// replace replaces the data between start and end with text
+func (s *source) replace(start, end int, text []byte) {
+	data := make([]byte, 0, len(s.data)-(end-start)+len(text))
+	data = append(data, s.data[:start]...)
+	data = append(data, text...)
+	s.data = append(data, s.data[end:]...)
+}
+
+// skip returns the offset after the whitespace and comments from i
+func (s *source) skip(i int) int {
+	for i < len(s.data) {
+		if isSpace(s.data[i]) {
+			i++
+		} else if n := commentLen(s.data[i:]); n > 0 {
+			i += n
+		} else {
+			break
+		}
+	}
+
+	return i
+}
+
+// lineEnd returns the offset after the comments following i in the same line,
+// or i if there is no comment, and whether the last comment is a line comment of //
+func (s *source) lineEnd(i int) (end int, comment bool) {
+	end = i
+	for k := i; k < len(s.data); {
+		switch c := s.data[k]; {
+		case c == ' ' || c == '\t':
+			k++
+		case c == '/':
+			n := commentLen(s.data[k:])
+			if n == 0 || strings.IndexByte(string(s.data[k:k+n]), '\n') >= 0 {
+				return
+			}
+			comment = s.data[k+1] == '/'
+			k += n
+			end = k
+		default:
+			return
+		}
+	}
+
+	return
+}
+
+// isSpace returns whether c is a json whitespace
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+	"github.com/likexian/gokit/xfile"
+)
+
+var textJSONC = `// config of server
+{
+    "name": "server", // the name
+    /* database
+       settings */
+    "db": {
+        "host": "127.0.0.1",
+        "port": 3306, // mysql
+    },
+    "tags": ["a", "b",],
+}
+`
+
+func Test_JSONC(t *testing.T) {
+	j := New()
+	err := j.Loads(textJSONC)
+	assert.NotNil(t, err)
+
+	j.SetJSONC(true)
+	err = j.Loads(textJSONC)
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("name").MustString(), "server")
+	assert.Equal(t, j.Get("db.port").MustInt(), 3306)
+	assert.Equal(t, j.Get("tags").MustStringArray(), []string{"a", "b"})
+
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"db":{"host":"127.0.0.1","port":3306},"name":"server","tags":["a","b"]}`)
+
+	valid := map[string]string{
+		`/**/1/**/`:          `1`,
+		"[1, // one\r\n2]":   `[1,2]`,
+		`{"a": /* / */ "b"}`: `{"a":"b"}`,
+		"1 // end":           `1`,
+		`[[],]`:              `[[]]`,
+	}
+
+	for k, v := range valid {
+		err := j.Loads(k)
+		assert.Nil(t, err, k)
+		s, err := j.Dumps()
+		assert.Nil(t, err, k)
+		assert.Equal(t, s, v, k)
+	}
+
+	invalid := []string{
+		`/* 1`, `[1 /* 2]`, `{,}`, `[,]`, `[1,,]`, `{"a":1,,}`, `/ 1`, `[1,`, `# 1`,
+	}
+
+	for _, v := range invalid {
+		err := j.Loads(v)
+		assert.NotNil(t, err, v)
+		_, ok := err.(*SyntaxError)
+		assert.True(t, ok, v)
+	}
+}
+
+func Test_Preserve(t *testing.T) {
+	j := New()
+	j.SetJSONC(true)
+	j.SetPreserve(true)
+
+	err := j.Loads(textJSONC)
+	assert.Nil(t, err)
+
+	s, err := j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, textJSONC)
+
+	j.Set("db.port", 3307)
+	j.Get("db").Set("user", "root")
+	j.Set("name", map[string]interface{}{"a": []int{1}})
+	j.Set("log.level.name", "info")
+	j.Del("tags")
+	assert.Equal(t, j.Get("db.port").MustInt(), 3307)
+	assert.Equal(t, j.Get("log.level.name").MustString(), "info")
+
+	s, err = j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `// config of server
+{
+    "name": {"a":[1]}, // the name
+    /* database
+       settings */
+    "db": {
+        "host": "127.0.0.1",
+        "port": 3307, // mysql
+        "user": "root",
+    },
+    "log": {
+        "level": {
+            "name": "info"
+        }
+    },
+}
+`)
+
+	j.Del("log")
+	j.Del("name")
+	j.Del("db.host")
+	j.Del("db.none")
+	s, err = j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `// config of server
+{
+    /* database
+       settings */
+    "db": {
+        "port": 3307, // mysql
+        "user": "root",
+    },
+}
+`)
+
+	j.Set("", []interface{}{1})
+	s, err = j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "// config of server\n[\n    1\n]\n")
+
+	s, err = j.Get("0").DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "1")
+
+	// Dumps is always json
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "[1]")
+
+	s, err = j.PrettyDumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "[\n    1\n]")
+}
+
+func Test_Preserve_Edit(t *testing.T) {
+	tests := []struct {
+		text   string
+		edit   func(*Json)
+		expect string
+	}{
+		{`{"a":1,"b":2}`, func(j *Json) { j.Set("c", 3) }, `{"a":1,"b":2,"c":3}`},
+		{`{ "a" : 1 }`, func(j *Json) { j.Set("b", 2) }, `{ "a" : 1, "b" : 2 }`},
+		{`{}`, func(j *Json) { j.Set("a.b", 1) }, `{"a": {"b": 1}}`},
+		{`{"a":{}}`, func(j *Json) { j.Set("a.b", 1) }, `{"a":{"b": 1}}`},
+		{`{"a":1,"b":2}`, func(j *Json) { j.Del("a") }, `{"b":2}`},
+		{`{"a":1, "b":2}`, func(j *Json) { j.Del("b") }, `{"a":1}`},
+		{`{"a":1}`, func(j *Json) { j.Del("a") }, `{}`},
+		{"{\n  \"a\": 1,\n  \"b\": 2\n}", func(j *Json) { j.Del("b") }, "{\n  \"a\": 1\n}"},
+		{"{\n  \"a\": 1, // a\n  \"b\": 2\n}", func(j *Json) { j.Del("b") }, "{\n  \"a\": 1 // a\n}"},
+		{"{\r\n  \"a\": 1, // a\r\n  \"b\": 2\r\n}", func(j *Json) { j.Del("a") }, "{\r\n  \"b\": 2\r\n}"},
+		{"{\r\n  \"a\": 1 // a\r\n}", func(j *Json) { j.Set("b", 2) }, "{\r\n  \"a\": 1, // a\r\n  \"b\": 2\r\n}"},
+		{`{"a":[1,{"b":2}]}`, func(j *Json) { j.Get("a").Index(1).Set("c", 3) }, `{"a":[1,{"b":2,"c":3}]}`},
+		{`{"a":"<"}`, func(j *Json) { j.Set("a", "<>") }, `{"a":"<>"}`},
+		{`{"a":1} trailing`, func(j *Json) { j.Set("a", 2) }, `{"a":2} trailing`},
+		{"{\"a\": 1, // note\n}", func(j *Json) { j.Set("b", 2) }, "{\"a\": 1,\"b\": 2, // note\n}"},
+		{"{\"a\": 1 // note\n}", func(j *Json) { j.Set("b", 2) }, "{\"a\": 1,\"b\": 2 // note\n}"},
+		{"{\"a\": 1 /* note */ // line\n}", func(j *Json) { j.Set("b", 2) }, "{\"a\": 1,\"b\": 2 /* note */ // line\n}"},
+		{"{\"a\": 1 /* note */\n}", func(j *Json) { j.Set("b", 2) }, "{\"a\": 1, /* note */\"b\": 2\n}"},
+	}
+
+	for _, v := range tests {
+		j := New()
+		j.SetJSONC(true)
+		j.SetPreserve(true)
+		err := j.Loads(v.text)
+		assert.Nil(t, err, v.text)
+		v.edit(j)
+		s, err := j.DumpsSource()
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, s, v.expect, v.text)
+
+		// the edited source is loaded as the edited data
+		expect, err := j.Dumps()
+		assert.Nil(t, err, v.text)
+		err = j.Loads(s)
+		assert.Nil(t, err, v.text)
+		s, err = j.Dumps()
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, s, expect, v.text)
+	}
+
+	j := New()
+	j.SetPreserve(true)
+	err := j.Loads(`{"a": 1}`)
+	assert.Nil(t, err)
+	j.Set("b", make(chan int))
+	_, err = j.DumpsSource()
+	assert.NotNil(t, err)
+	j.Del("b")
+	j.Set("c", 1)
+	_, err = j.DumpsSource()
+	assert.NotNil(t, err)
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":1,"c":1}`)
+
+	// Dumps, DumpBytes, WriteTo and LinesWriter write json without comments
+	j = New()
+	j.SetJSONC(true)
+	j.SetPreserve(true)
+	err = j.Loads("{\"a\": 1, // c\n}")
+	assert.Nil(t, err)
+	s, err = j.DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "{\"a\": 1, // c\n}")
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":1}`)
+	b, err := j.DumpBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), `{"a":1}`)
+
+	var buf bytes.Buffer
+	_, err = j.WriteTo(&buf)
+	assert.Nil(t, err)
+	err = NewLinesWriter(&buf).Encode(j)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "{\"a\":1}{\"a\":1}\n")
+
+	// Not in preserve mode
+	s, err = New([]int{1}).DumpsSource()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "[\n    1\n]")
+}
+
+func Test_Preserve_Dump(t *testing.T) {
+	defer os.Remove("preserve.jsonc")
+
+	err := xfile.WriteText("preserve.jsonc", textJSONC)
+	assert.Nil(t, err)
+
+	j := New()
+	j.SetJSONC(true)
+	j.SetPreserve(true)
+	err = j.Load("preserve.jsonc")
+	assert.Nil(t, err)
+
+	j.Set("db.host", "localhost")
+	err = j.Dump("preserve.jsonc")
+	assert.Nil(t, err)
+
+	text, err := xfile.ReadText("preserve.jsonc")
+	assert.Nil(t, err)
+	assert.Equal(t, text, strings.Replace(textJSONC, `"127.0.0.1"`, `"localhost"`, 1))
+}
+
+func Test_Preserve_Format(t *testing.T) {
+	tests := []struct {
+		text   string
+		edit   func(*Json)
+		expect string
+	}{
+		{
+			"{\n\t\"a\": {\n\t\t\"b\": 1\n\t}\n}",
+			func(j *Json) { j.Set("a.c", []int{1, 2}) },
+			"{\n\t\"a\": {\n\t\t\"b\": 1,\n\t\t\"c\": [\n\t\t\t1,\n\t\t\t2\n\t\t]\n\t}\n}",
+		},
+		{
+			"{\n  \"a\": 1\n}",
+			func(j *Json) { j.Set("b.c", 2) },
+			"{\n  \"a\": 1,\n  \"b\": {\n    \"c\": 2\n  }\n}",
+		},
+		{
+			"{\r\n  \"a\": {\r\n    \"b\": 1\r\n  }\r\n}",
+			func(j *Json) { j.Set("a", map[string]int{"c": 2}) },
+			"{\r\n  \"a\": {\r\n    \"c\": 2\r\n  }\r\n}",
+		},
+		{
+			"{\n  \"a\": {\"b\": 1}\n}",
+			func(j *Json) { j.Set("a", map[string]int{"c": 2}) },
+			"{\n  \"a\": {\"c\":2}\n}",
+		},
+		{
+			"{\n}",
+			func(j *Json) { j.Set("b.c", 1); j.Set("z", 1) },
+			"{\n    \"b\": {\n        \"c\": 1\n    },\n    \"z\": 1\n}",
+		},
+		{
+			"{\n  \"a\": {\n  },\n  \"c\": 2\n}",
+			func(j *Json) { j.Set("a.b", 1); j.Set("a.d", 2) },
+			"{\n  \"a\": {\n    \"b\": 1,\n    \"d\": 2\n  },\n  \"c\": 2\n}",
+		},
+		{
+			"{\r\n\t\"a\": {}\r\n}",
+			func(j *Json) { j.Set("a.b", 1) },
+			"{\r\n\t\"a\": {\"b\": 1}\r\n}",
+		},
+		{
+			"{\n  \"a\": {\n    \"b\": 1\n  },\n  \"c\": 2\n}",
+			func(j *Json) { j.Del("a.b") },
+			"{\n  \"a\": {},\n  \"c\": 2\n}",
+		},
+		{
+			`{ "a": 1 }`,
+			func(j *Json) { j.Del("a") },
+			`{}`,
+		},
+		{
+			"{\"a\": 1, \"b\": {\n   \"c\": {\n      \"d\": 1\n   }\n}}",
+			func(j *Json) { j.Set("b.c.e", true) },
+			"{\"a\": 1, \"b\": {\n   \"c\": {\n      \"d\": 1,\n      \"e\": true\n   }\n}}",
+		},
+	}
+
+	for _, v := range tests {
+		j := New()
+		j.SetPreserve(true)
+		err := j.Loads(v.text)
+		assert.Nil(t, err, v.text)
+		v.edit(j)
+		s, err := j.DumpsSource()
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, s, v.expect, v.text)
+	}
+
+	text := `{
+  "version": "1.0.0",
+  "zzz": {"enabled": true},
+  "dependencies": {
+    "a": "^1.0.0",
+    "b": "^2.0.0"
+  },
+  "aaa": [1, 2, 3]
+}
+`
+
+	j := New()
+	j.SetPreserve(true)
+	err := j.Loads(text)
+	assert.Nil(t, err)
+
+	j.Set("dependencies.b", "^2.1.0")
+	s, err := j.DumpsSource()
+	assert.Nil(t, err)
+
+	changed := 0
+	lines := strings.Split(text, "\n")
+	for i, v := range strings.Split(s, "\n") {
+		if v != lines[i] {
+			changed++
+			assert.Equal(t, v, `    "b": "^2.1.0"`)
+		}
+	}
+	assert.Equal(t, changed, 1)
+}
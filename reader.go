@@ -0,0 +1,190 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Reader reads values from json object by key, and records every failed reading
+//   r := json.Reader()
+//   code := r.Int("status.code")
+//   message := r.String("status.message", "")
+//   if err := r.Err(); err != nil {
+//       return err
+//   }
+type Reader struct {
+	json   *Json
+	errors Errors
+}
+
+// Errors is the list of errors recorded by Reader
+type Errors []*PathError
+
+// Error returns the string of errors
+func (e Errors) Error() string {
+	result := make([]string, len(e))
+	for i, v := range e {
+		result[i] = v.Error()
+	}
+
+	return strings.Join(result, "; ")
+}
+
+// Reader returns a new Reader of json object
+func (j *Json) Reader() *Reader {
+	return &Reader{
+		json: j,
+	}
+}
+
+// Err returns the Errors of all failed reading, nil if there is none
+func (r *Reader) Err() error {
+	if len(r.errors) == 0 {
+		return nil
+	}
+
+	return r.errors
+}
+
+// Map returns as map at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Map(key string, args ...map[string]interface{}) (result map[string]interface{}) {
+	r.try(func() { result = r.json.Get(key).MustMap(args...) })
+	return
+}
+
+// Array returns as array at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Array(key string, args ...[]interface{}) (result []interface{}) {
+	r.try(func() { result = r.json.Get(key).MustArray(args...) })
+	return
+}
+
+// Bool returns as bool at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Bool(key string, args ...bool) (result bool) {
+	r.try(func() { result = r.json.Get(key).MustBool(args...) })
+	return
+}
+
+// String returns as string at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) String(key string, args ...string) (result string) {
+	r.try(func() { result = r.json.Get(key).MustString(args...) })
+	return
+}
+
+// StringArray returns as string array at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) StringArray(key string, args ...[]string) (result []string) {
+	r.try(func() { result = r.json.Get(key).MustStringArray(args...) })
+	return
+}
+
+// Time returns as time.Time at key of json object, args is the same as MustTime
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Time(key string, args ...interface{}) (result time.Time) {
+	r.try(func() { result = r.json.Get(key).MustTime(args...) })
+	return
+}
+
+// TimeWith returns as time.Time at key of json object using the format with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) TimeWith(key string, format TimeFormat, args ...time.Time) (result time.Time) {
+	r.try(func() { result = r.json.Get(key).MustTimeWith(format, args...) })
+	return
+}
+
+// Float64 returns as float64 at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Float64(key string, args ...float64) (result float64) {
+	r.try(func() { result = r.json.Get(key).MustFloat64(args...) })
+	return
+}
+
+// Int returns as int at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Int(key string, args ...int) (result int) {
+	r.try(func() { result = r.json.Get(key).MustInt(args...) })
+	return
+}
+
+// Int64 returns as int64 at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Int64(key string, args ...int64) (result int64) {
+	r.try(func() { result = r.json.Get(key).MustInt64(args...) })
+	return
+}
+
+// Uint64 returns as uint64 at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Uint64(key string, args ...uint64) (result uint64) {
+	r.try(func() { result = r.json.Get(key).MustUint64(args...) })
+	return
+}
+
+// Bytes returns as []byte at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) Bytes(key string, args ...[]byte) (result []byte) {
+	r.try(func() { result = r.json.Get(key).MustBytes(args...) })
+	return
+}
+
+// URL returns as *url.URL at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) URL(key string, args ...*url.URL) (result *url.URL) {
+	r.try(func() { result = r.json.Get(key).MustURL(args...) })
+	return
+}
+
+// IP returns as net.IP at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) IP(key string, args ...net.IP) (result net.IP) {
+	r.try(func() { result = r.json.Get(key).MustIP(args...) })
+	return
+}
+
+// CIDR returns as *net.IPNet at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) CIDR(key string, args ...*net.IPNet) (result *net.IPNet) {
+	r.try(func() { result = r.json.Get(key).MustCIDR(args...) })
+	return
+}
+
+// UUID returns as [16]byte at key of json object with optional default value
+// if error return default(if set) or zero value, and record the error
+func (r *Reader) UUID(key string, args ...[16]byte) (result [16]byte) {
+	r.try(func() { result = r.json.Get(key).MustUUID(args...) })
+	return
+}
+
+// try calls fn and records the error panicked by MustXXX
+func (r *Reader) try(fn func()) {
+	err := Try(fn)
+	if err != nil {
+		e := err.(*PathError)
+		e.Op = strings.TrimPrefix(e.Op, "Must")
+		r.errors = append(r.errors, e)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Reader(t *testing.T) {
+	jsonData, err := Loads(textResult)
+	assert.Nil(t, err)
+
+	jsonData.Set("time", "2019-01-31T12:11:10+08:00")
+	jsonData.Set("bytes", "aGk=")
+	jsonData.Set("url", "https://www.likexian.com/")
+	jsonData.Set("ip", "192.0.2.1")
+	jsonData.Set("cidr", "192.0.2.0/24")
+	jsonData.Set("uuid", "123e4567-e89b-12d3-a456-426614174000")
+	jsonData.Set("strings", []interface{}{"a", "b"})
+
+	// All success
+	r := jsonData.Reader()
+	assert.Len(t, r.Map("status"), 2)
+	assert.Len(t, r.Array("result.intlist"), 5)
+	assert.True(t, r.Bool("result.online"))
+	assert.Equal(t, r.String("status.message"), "success")
+	assert.Equal(t, r.StringArray("strings"), []string{"a", "b"})
+	assert.Equal(t, r.Time("time").Unix(), int64(1548907870))
+	assert.Equal(t, r.TimeWith("time", TimeFormat{}).Unix(), int64(1548907870))
+	assert.Equal(t, r.Float64("result.rate"), 0.8)
+	assert.Equal(t, r.Int("status.code"), 1)
+	assert.Equal(t, r.Int64("status.code"), int64(1))
+	assert.Equal(t, r.Uint64("status.code"), uint64(1))
+	assert.Equal(t, r.Bytes("bytes"), []byte("hi"))
+	assert.Equal(t, r.URL("url").Host, "www.likexian.com")
+	assert.Equal(t, r.IP("ip").String(), "192.0.2.1")
+	assert.Equal(t, r.CIDR("cidr").String(), "192.0.2.0/24")
+	assert.Equal(t, r.UUID("uuid")[0], byte(0x12))
+	assert.Nil(t, r.Err())
+
+	// Default value is not error
+	r = jsonData.Reader()
+	assert.Equal(t, r.Int("not-exists", 8), 8)
+	assert.Equal(t, r.String("not-exists", "def"), "def")
+	assert.Equal(t, r.IP("not-exists", net.IPv6loopback), net.IPv6loopback)
+	assert.Equal(t, r.Time("not-exists", time.Unix(0, 0)), time.Unix(0, 0))
+	assert.Nil(t, r.Err())
+
+	// All errors are recorded
+	r = jsonData.Reader()
+	assert.Len(t, r.Map("status.code"), 0)
+	assert.Len(t, r.Array("status"), 0)
+	assert.False(t, r.Bool("status.code"))
+	assert.Equal(t, r.String("status.code"), "")
+	assert.Len(t, r.StringArray("result.intlist"), 0)
+	assert.True(t, r.Time("status").IsZero())
+	assert.True(t, r.TimeWith("status", TimeFormat{}).IsZero())
+	assert.Equal(t, r.Float64("status"), float64(0))
+	assert.Equal(t, r.Int("status.message"), 0)
+	assert.Equal(t, r.Int64("status"), int64(0))
+	assert.Equal(t, r.Uint64("status"), uint64(0))
+	assert.Len(t, r.Bytes("status"), 0)
+	assert.True(t, r.URL("status") == nil)
+	assert.Len(t, r.IP("status"), 0)
+	assert.True(t, r.CIDR("status") == nil)
+	assert.Equal(t, r.UUID("status"), [16]byte{})
+	assert.Equal(t, r.Int("status.code", 1, 2), 0)
+
+	err = r.Err()
+	assert.NotNil(t, err)
+	errs, ok := err.(Errors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 17)
+	assert.Equal(t, errs[0].Op, "Map")
+	assert.Equal(t, errs[0].Path, "status.code")
+	assert.Equal(t, errs[8].Op, "Int")
+	assert.Equal(t, errs[8].Path, "status.message")
+	assert.Equal(t, errs[16].Err, ErrTooManyArguments)
+	assert.Contains(t, err.Error(), "simplejson: Map status.code: assert to map failed; simplejson: Array status: ")
+}
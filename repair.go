@@ -0,0 +1,467 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of Fix applied by Repair
+const (
+	FixSingleQuote   = "single quoted string"
+	FixUnquotedKey   = "unquoted key"
+	FixTrailingComma = "trailing comma"
+	FixExtraComma    = "extra comma"
+	FixMissingComma  = "missing comma"
+	FixPythonLiteral = "python literal"
+	FixComment       = "comment"
+	FixTruncated     = "truncated"
+)
+
+// Fix is a fix applied by Repair
+type Fix struct {
+	// Offset is the byte offset in the original text
+	Offset int
+	// Kind is the kind of fix, one of FixXXX
+	Kind string
+}
+
+// repairer repairs malformed json text
+type repairer struct {
+	data  []byte
+	pos   int
+	out   bytes.Buffer
+	fixes []Fix
+	depth int
+}
+
+// repairNumber is the regexp of valid json number
+var repairNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// String returns the string of fix
+func (f Fix) String() string {
+	return fmt.Sprintf("%s at offset %d", f.Kind, f.Offset)
+}
+
+// Repair repairs malformed json text, returns the repaired text and the fixes applied
+// single quoted strings, unquoted keys, trailing, extra and missing commas, python literals
+// True, False and None, comments of //, /* */ and #, and truncated endings are fixed
+//   Repair(`{name: 'Li Kexian', ok: True,}`) // {"name":"Li Kexian","ok":true}
+func Repair(text string) (string, []Fix, error) {
+	r := &repairer{
+		data:  []byte(text),
+		fixes: []Fix{},
+	}
+
+	err := r.repair()
+	if err != nil {
+		return "", r.fixes, err
+	}
+
+	return r.out.String(), r.fixes, nil
+}
+
+// LoadsRepair repairs malformed json text and unmarshal it, returns json object and the fixes applied
+func LoadsRepair(text string) (*Json, []Fix, error) {
+	j := New()
+	fixes, err := j.LoadsRepair(text)
+	return j, fixes, err
+}
+
+// LoadsRepair repairs malformed json text and unmarshal it, returns the fixes applied
+func (j *Json) LoadsRepair(text string) ([]Fix, error) {
+	result, fixes, err := Repair(text)
+	if err != nil {
+		return fixes, err
+	}
+
+	return fixes, j.Loads(result)
+}
+
+// repair repairs the whole data as one json value
+func (r *repairer) repair() error {
+	r.skip()
+	if r.pos >= len(r.data) {
+		return r.errorf("unexpected end of JSON input")
+	}
+
+	err := r.value()
+	if err != nil {
+		return err
+	}
+
+	r.skip()
+	if r.pos < len(r.data) {
+		return r.errorf("invalid character %s after top-level value", quoteChar(r.data[r.pos]))
+	}
+
+	if !json.Valid(r.out.Bytes()) {
+		return r.errorf("unable to repair")
+	}
+
+	return nil
+}
+
+// value repairs a json value
+func (r *repairer) value() error {
+	if r.pos >= len(r.data) {
+		r.fix(FixTruncated)
+		r.out.WriteString("null")
+		return nil
+	}
+
+	switch c := r.data[r.pos]; {
+	case c == '{':
+		return r.object()
+	case c == '[':
+		return r.array()
+	case c == '"' || c == '\'':
+		return r.string()
+	case c == '-' || c >= '0' && c <= '9':
+		return r.number()
+	case isIdentifier(c):
+		return r.literal()
+	default:
+		return r.errorf("invalid character %s looking for beginning of value", quoteChar(c))
+	}
+}
+
+// object repairs a json object
+func (r *repairer) object() error {
+	err := r.enter()
+	if err != nil {
+		return err
+	}
+	defer r.leave()
+
+	r.out.WriteByte('{')
+	r.pos++
+
+	for n := 0; ; n++ {
+		r.skipCommas()
+		if r.pos >= len(r.data) {
+			r.fix(FixTruncated)
+			r.out.WriteByte('}')
+			return nil
+		}
+
+		c := r.data[r.pos]
+		if c == '}' {
+			r.out.WriteByte('}')
+			r.pos++
+			return nil
+		}
+
+		if n > 0 {
+			r.out.WriteByte(',')
+		}
+
+		switch {
+		case c == '"' || c == '\'':
+			err := r.string()
+			if err != nil {
+				return err
+			}
+		case isIdentifier(c):
+			r.fix(FixUnquotedKey)
+			start := r.pos
+			for r.pos < len(r.data) && isIdentifier(r.data[r.pos]) {
+				r.pos++
+			}
+			r.out.WriteString(strconv.Quote(string(r.data[start:r.pos])))
+		default:
+			return r.errorf("invalid character %s looking for beginning of object key string", quoteChar(c))
+		}
+
+		r.skip()
+		if r.pos >= len(r.data) {
+			r.fix(FixTruncated)
+			r.out.WriteString(":null}")
+			return nil
+		}
+
+		if r.data[r.pos] != ':' {
+			return r.errorf("invalid character %s after object key", quoteChar(r.data[r.pos]))
+		}
+
+		r.out.WriteByte(':')
+		r.pos++
+		r.skip()
+
+		err := r.value()
+		if err != nil {
+			return err
+		}
+
+		err = r.separator('}')
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// array repairs a json array
+func (r *repairer) array() error {
+	err := r.enter()
+	if err != nil {
+		return err
+	}
+	defer r.leave()
+
+	r.out.WriteByte('[')
+	r.pos++
+
+	for n := 0; ; n++ {
+		r.skipCommas()
+		if r.pos >= len(r.data) {
+			r.fix(FixTruncated)
+			r.out.WriteByte(']')
+			return nil
+		}
+
+		if r.data[r.pos] == ']' {
+			r.out.WriteByte(']')
+			r.pos++
+			return nil
+		}
+
+		if n > 0 {
+			r.out.WriteByte(',')
+		}
+
+		err := r.value()
+		if err != nil {
+			return err
+		}
+
+		err = r.separator(']')
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// separator skips the comma after an element, fixes the trailing and missing comma
+func (r *repairer) separator(end byte) error {
+	r.skip()
+	if r.pos >= len(r.data) {
+		return nil
+	}
+
+	switch r.data[r.pos] {
+	case ',':
+		comma := r.pos
+		r.pos++
+		r.skip()
+		if r.pos < len(r.data) && r.data[r.pos] == end {
+			r.fixAt(comma, FixTrailingComma)
+		}
+	case end:
+	case ':', '}', ']':
+		return r.errorf("invalid character %s after element", quoteChar(r.data[r.pos]))
+	default:
+		r.fix(FixMissingComma)
+	}
+
+	return nil
+}
+
+// skipCommas skips the leading or doubled commas before an element
+func (r *repairer) skipCommas() {
+	r.skip()
+	for r.pos < len(r.data) && r.data[r.pos] == ',' {
+		r.fix(FixExtraComma)
+		r.pos++
+		r.skip()
+	}
+}
+
+// string repairs a double or single quoted string
+func (r *repairer) string() error {
+	quote := r.data[r.pos]
+	if quote == '\'' {
+		r.fix(FixSingleQuote)
+	}
+
+	r.out.WriteByte('"')
+	r.pos++
+
+	for r.pos < len(r.data) {
+		c := r.data[r.pos]
+		switch {
+		case c == quote:
+			r.out.WriteByte('"')
+			r.pos++
+			return nil
+		case c == '\\':
+			if r.pos+1 >= len(r.data) {
+				r.pos++
+				continue
+			}
+			e := r.data[r.pos+1]
+			if e == '\'' {
+				r.out.WriteByte('\'')
+			} else {
+				r.out.Write(r.data[r.pos : r.pos+2])
+			}
+			r.pos += 2
+		case c == '"':
+			r.out.WriteString(`\"`)
+			r.pos++
+		default:
+			r.out.WriteByte(c)
+			r.pos++
+		}
+	}
+
+	r.fix(FixTruncated)
+	r.out.WriteByte('"')
+
+	return nil
+}
+
+// number repairs a json number, truncated number is cut to the valid part
+func (r *repairer) number() error {
+	start := r.pos
+	for r.pos < len(r.data) && strings.IndexByte("+-.eE0123456789", r.data[r.pos]) >= 0 {
+		r.pos++
+	}
+
+	text := string(r.data[start:r.pos])
+	if repairNumber.MatchString(text) {
+		r.out.WriteString(text)
+		return nil
+	}
+
+	if r.pos >= len(r.data) {
+		for i := len(text) - 1; i > 0; i-- {
+			if repairNumber.MatchString(text[:i]) {
+				r.fix(FixTruncated)
+				r.out.WriteString(text[:i])
+				return nil
+			}
+		}
+	}
+
+	r.pos = start
+
+	return r.errorf("invalid number literal %q", text)
+}
+
+// literal repairs the literals true, false and null, python literals True, False and None
+func (r *repairer) literal() error {
+	start := r.pos
+	for r.pos < len(r.data) && isIdentifier(r.data[r.pos]) {
+		r.pos++
+	}
+
+	text := string(r.data[start:r.pos])
+	switch text {
+	case "true", "false", "null":
+		r.out.WriteString(text)
+		return nil
+	case "True", "False", "None":
+		r.fixAt(start, FixPythonLiteral)
+		r.out.WriteString(map[string]string{"True": "true", "False": "false", "None": "null"}[text])
+		return nil
+	}
+
+	if r.pos >= len(r.data) {
+		for _, v := range []string{"true", "false", "null"} {
+			if strings.HasPrefix(v, text) {
+				r.fix(FixTruncated)
+				r.out.WriteString(v)
+				return nil
+			}
+		}
+	}
+
+	r.pos = start
+
+	return r.errorf("invalid literal %q", text)
+}
+
+// skip skips the whitespace and comments
+func (r *repairer) skip() {
+	for r.pos < len(r.data) {
+		switch c := r.data[r.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			r.pos++
+		case c == '#' || c == '/' && r.pos+1 < len(r.data) && r.data[r.pos+1] == '/':
+			r.fix(FixComment)
+			for r.pos < len(r.data) && r.data[r.pos] != '\n' {
+				r.pos++
+			}
+		case c == '/' && r.pos+1 < len(r.data) && r.data[r.pos+1] == '*':
+			r.fix(FixComment)
+			end := bytes.Index(r.data[r.pos+2:], []byte("*/"))
+			if end < 0 {
+				r.fix(FixTruncated)
+				r.pos = len(r.data)
+			} else {
+				r.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+// fix records a fix at pos
+func (r *repairer) fix(kind string) {
+	r.fixAt(r.pos, kind)
+}
+
+// fixAt records a fix at offset
+func (r *repairer) fixAt(offset int, kind string) {
+	r.fixes = append(r.fixes, Fix{Offset: offset, Kind: kind})
+}
+
+// enter enters a nested array or object, returns the error if the depth exceeds maxDepth
+func (r *repairer) enter() error {
+	r.depth++
+	if r.depth > maxDepth {
+		e := newSyntaxError(r.data, r.pos, fmt.Sprintf("exceeded max depth of %d", maxDepth))
+		e.Err = ErrLimitExceeded
+		return e
+	}
+
+	return nil
+}
+
+// leave leaves a nested array or object
+func (r *repairer) leave() {
+	r.depth--
+}
+
+// errorf returns the *SyntaxError at pos
+func (r *repairer) errorf(format string, args ...interface{}) error {
+	return newSyntaxError(r.data, r.pos, fmt.Sprintf(format, args...))
+}
+
+// isIdentifier returns whether c is a character of identifier
+func isIdentifier(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '$'
+}
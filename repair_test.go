@@ -0,0 +1,100 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Repair(t *testing.T) {
+	tests := []struct {
+		text   string
+		expect string
+		kinds  []string
+	}{
+		{`{"a": 1}`, `{"a":1}`, []string{}},
+		{`{'a': 'it\'s "ok"'}`, `{"a":"it's \"ok\""}`, []string{FixSingleQuote, FixSingleQuote}},
+		{`{name: "Li", $id_2: 1}`, `{"name":"Li","$id_2":1}`, []string{FixUnquotedKey, FixUnquotedKey}},
+		{`{"a": [1, 2,], }`, `{"a":[1,2]}`, []string{FixTrailingComma, FixTrailingComma}},
+		{`[1,,2]`, `[1,2]`, []string{FixExtraComma}},
+		{`[,1]`, `[1]`, []string{FixExtraComma}},
+		{`{, "a": 1,, "b": 2}`, `{"a":1,"b":2}`, []string{FixExtraComma, FixExtraComma}},
+		{`{"a": 1 "b": 2}`, `{"a":1,"b":2}`, []string{FixMissingComma}},
+		{"[1\n2\n3]", `[1,2,3]`, []string{FixMissingComma, FixMissingComma}},
+		{`[True, False, None, true, false, null]`, `[true,false,null,true,false,null]`, []string{FixPythonLiteral, FixPythonLiteral, FixPythonLiteral}},
+		{"{\n// comment\n\"a\": 1, # comment\n/* multi\nline */ \"b\": 2}", `{"a":1,"b":2}`, []string{FixComment, FixComment, FixComment}},
+		{`{"a": [1, {"b": "c`, `{"a":[1,{"b":"c"}]}`, []string{FixTruncated, FixTruncated, FixTruncated, FixTruncated}},
+		{`{"a": `, `{"a":null}`, []string{FixTruncated, FixTruncated}},
+		{`{"a"`, `{"a":null}`, []string{FixTruncated}},
+		{`{a`, `{"a":null}`, []string{FixUnquotedKey, FixTruncated}},
+		{`[1.`, `[1]`, []string{FixTruncated, FixTruncated}},
+		{`[1.5e-`, `[1.5]`, []string{FixTruncated, FixTruncated}},
+		{`[tr`, `[true]`, []string{FixTruncated, FixTruncated}},
+		{`["a\`, `["a"]`, []string{FixTruncated, FixTruncated}},
+		{`[1, /* comment`, `[1]`, []string{FixComment, FixTruncated, FixTruncated}},
+	}
+
+	for _, v := range tests {
+		s, fixes, err := Repair(v.text)
+		assert.Nil(t, err, v.text)
+		assert.Equal(t, s, v.expect, v.text)
+		kinds := []string{}
+		for _, f := range fixes {
+			kinds = append(kinds, f.Kind)
+		}
+		assert.Equal(t, kinds, v.kinds, v.text)
+	}
+
+	// Offset of fix
+	_, fixes, err := Repair(`{a: 1, 'b': True,}`)
+	assert.Nil(t, err)
+	assert.Equal(t, fixes, []Fix{{1, FixUnquotedKey}, {7, FixSingleQuote}, {12, FixPythonLiteral}, {16, FixTrailingComma}})
+	assert.Equal(t, fixes[0].String(), "unquoted key at offset 1")
+
+	_, fixes, err = Repair("[1,   ]")
+	assert.Nil(t, err)
+	assert.Equal(t, fixes, []Fix{{2, FixTrailingComma}})
+	_, fixes, err = Repair("{\"a\": 1 ,\n}")
+	assert.Nil(t, err)
+	assert.Equal(t, fixes, []Fix{{8, FixTrailingComma}})
+	_, fixes, err = Repair("[1, ,2]")
+	assert.Nil(t, err)
+	assert.Equal(t, fixes, []Fix{{4, FixExtraComma}})
+
+	// Unable to repair
+	for _, v := range []string{``, ` // comment`, `{"a": 1}}`, `{"a": @}`, `{@: 1}`, `{"a" 1}`, `[1 :]`, `[1-2]`, `[1.x]`, `[abc]`, "[\"\t\"]"} {
+		_, _, err := Repair(v)
+		assert.NotNil(t, err, v)
+	}
+}
+
+func Test_Loads_Repair(t *testing.T) {
+	j, fixes, err := LoadsRepair(`{status: {code: 1, message: 'success',}, result: {online: True, rate: 0.8, intlist: [0, 1, 2, 3, 4`)
+	assert.Nil(t, err)
+	assert.Len(t, fixes, 13)
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, textResult)
+
+	_, _, err = LoadsRepair(`{"a": @}`)
+	assert.NotNil(t, err)
+}
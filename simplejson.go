@@ -23,6 +23,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -33,8 +37,23 @@ import (
 
 // Json storing json data
 type Json struct {
-	data       interface{}
-	escapeHtml bool
+	data        interface{}
+	escapeHtml  bool
+	weakType    bool
+	strict      bool
+	path        string
+	position    bool
+	positions   *positions
+	jsonc       bool
+	preserve    bool
+	source      *source
+	ordered     bool
+	duplicate   DuplicateKey
+	duplicates  []string
+	limits      Limits
+	invalidUTF8 InvalidUTF8
+	asciiEscape bool
+	nonFinite   NonFinite
 }
 
 // Version returns package version
@@ -83,6 +102,41 @@ func Loads(text string) (*Json, error) {
 	return j, err
 }
 
+// LoadReader loads data from io.Reader, returns a json object
+func LoadReader(r io.Reader) (*Json, error) {
+	j := New()
+	err := j.LoadReader(r)
+	return j, err
+}
+
+// LoadBytes unmarshal json from bytes, returns a json object
+func LoadBytes(data []byte) (*Json, error) {
+	j := New()
+	err := j.LoadBytes(data)
+	return j, err
+}
+
+// LoadsAll unmarshal all concatenated json values from string, returns json objects
+// if the data is invalid, the values before it and *SyntaxError are returned
+//   LoadsAll(`{"id": 1} {"id": 2} [3]`)
+func LoadsAll(text string) ([]*Json, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+
+	result := []*Json{}
+	for {
+		j := New()
+		err := dec.Decode(&j.data)
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, decodeError(&tail{data: []byte(text)}, err)
+		}
+		result = append(result, j)
+	}
+}
+
 // Dump dumps json object to a file
 func Dump(path string, data interface{}) error {
 	return New(data).Dump(path)
@@ -98,38 +152,126 @@ func PrettyDumps(data interface{}) (string, error) {
 	return New(data).PrettyDumps()
 }
 
+// DumpBytes marshal json object to bytes
+func DumpBytes(data interface{}) ([]byte, error) {
+	return New(data).DumpBytes()
+}
+
 // Load loads data from file, returns a json object
+// if the data is invalid, *SyntaxError with the file name is returned
 func (j *Json) Load(path string) error {
-	text, err := xfile.ReadText(path)
+	data, err := readFile(path, j.limits.MaxSize)
 	if err != nil {
 		return err
 	}
 
-	return j.Loads(text)
+	err = j.LoadBytes(data)
+	if e, ok := err.(*SyntaxError); ok {
+		e.File = path
+	}
+
+	if err == nil && j.positions != nil {
+		j.positions.file = path
+	}
+
+	return err
 }
 
 // Loads unmarshal json from string, returns json object
+// if the data is invalid, *SyntaxError is returned
 func (j *Json) Loads(text string) error {
-	dec := json.NewDecoder(bytes.NewBuffer([]byte(text)))
+	return j.decode(strings.NewReader(text), func() *tail {
+		return &tail{data: []byte(text)}
+	})
+}
+
+// LoadBytes unmarshal json from bytes, returns json object
+// if the data is invalid, *SyntaxError is returned
+func (j *Json) LoadBytes(data []byte) error {
+	return j.decode(bytes.NewReader(data), func() *tail {
+		return &tail{data: data}
+	})
+}
+
+// LoadReader loads data from io.Reader, returns json object
+// only the first json value is decoded, the reader may be read beyond it,
+// if strict mode is on, data after the first json value is an error,
+// if the data is invalid, *SyntaxError is returned
+func (j *Json) LoadReader(r io.Reader) error {
+	t := &tail{max: tailSize}
+	return j.decode(io.TeeReader(r, t), func() *tail {
+		return t
+	})
+}
+
+// decode decodes the first json value from r, read returns the tail of data read from r for error
+func (j *Json) decode(r io.Reader, read func() *tail) error {
+	j.positions = nil
+	j.source = nil
+	j.duplicates = nil
+	if j.position || j.jsonc || j.preserve || j.ordered || j.duplicate != 0 || j.limits != (Limits{}) || j.invalidUTF8 != 0 ||
+		j.nonFinite == NonFiniteLiteral {
+		if j.limits.MaxSize > 0 {
+			r = io.LimitReader(r, int64(j.limits.MaxSize)+1)
+		}
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return j.parse(data, false)
+	}
+
+	c := &countReader{reader: r}
+	dec := json.NewDecoder(c)
 	dec.UseNumber()
 	err := dec.Decode(&j.data)
+	if err != nil {
+		return decodeError(read(), err)
+	}
 
-	return err
+	if !j.strict {
+		return nil
+	}
+
+	buffered, _ := ioutil.ReadAll(dec.Buffered())
+	offset := c.n - len(buffered)
+
+	if _, err := dec.Token(); err != io.EOF {
+		t := read()
+		for offset < t.end() && offset >= t.offset && strings.IndexByte(" \t\r\n", t.data[offset-t.offset]) >= 0 {
+			offset++
+		}
+		if offset < t.end() && offset >= t.offset {
+			return t.syntaxError(offset, fmt.Sprintf("invalid character %s after top-level value", quoteChar(t.data[offset-t.offset])))
+		}
+		return t.syntaxError(offset, "invalid character after top-level value")
+	}
+
+	return nil
 }
 
 // Dump dumps json object to a file
+// in preserve mode the edited source is dumped
 func (j *Json) Dump(path string) (err error) {
-	result, err := j.PrettyDumps()
+	result, ok, err := j.edited()
+	if !ok {
+		result, err = j.doDumpBytes(strings.Repeat(" ", 4))
+	}
 	if err != nil {
 		return
 	}
 
-	return xfile.WriteText(path, result)
+	return xfile.Write(path, result)
 }
 
 // Dumps marshal json object to string
 func (j *Json) Dumps() (result string, err error) {
-	return j.doDumps("")
+	data, err := j.DumpBytes()
+	if err != nil {
+		return
+	}
+
+	return string(data), nil
 }
 
 // PrettyDumps marshal json object to string, with identation
@@ -137,20 +279,62 @@ func (j *Json) PrettyDumps() (result string, err error) {
 	return j.doDumps(strings.Repeat(" ", 4))
 }
 
+// DumpBytes marshal json object to bytes
+func (j *Json) DumpBytes() (result []byte, err error) {
+	return j.doDumpBytes("")
+}
+
+// WriteTo writes json object to io.Writer, the same as Dumps, implements io.WriterTo
+func (j *Json) WriteTo(w io.Writer) (n int64, err error) {
+	result, err := j.DumpBytes()
+	if err != nil {
+		return
+	}
+
+	m, err := w.Write(result)
+
+	return int64(m), err
+}
+
 // do marshal json to string
 func (j *Json) doDumps(indent string) (result string, err error) {
-	var buf bytes.Buffer
-
-	enc := json.NewEncoder(&buf)
-	enc.SetEscapeHTML(j.escapeHtml)
-	enc.SetIndent("", indent)
-	err = enc.Encode(j.data)
+	data, err := j.doDumpBytes(indent)
 	if err != nil {
 		return
 	}
 
-	result = buf.String()
-	result = strings.TrimSpace(result)
+	return string(data), nil
+}
+
+// do marshal json to bytes
+func (j *Json) doDumpBytes(indent string) (result []byte, err error) {
+	if j.nonFinite != 0 {
+		e := &encoder{
+			escapeHtml: j.escapeHtml,
+			nonFinite:  j.nonFinite,
+			indent:     indent,
+			base:       j.path,
+		}
+		err = e.encode(reflect.ValueOf(j.data))
+		if err != nil {
+			return
+		}
+		result = e.buf.Bytes()
+	} else {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(j.escapeHtml)
+		enc.SetIndent("", indent)
+		err = enc.Encode(j.data)
+		if err != nil {
+			return
+		}
+		result = bytes.TrimSpace(buf.Bytes())
+	}
+
+	if j.asciiEscape {
+		result = escapeASCII(result)
+	}
 
 	return
 }
@@ -160,34 +344,58 @@ func (j *Json) SetHtmlEscape(escape bool) {
 	j.escapeHtml = escape
 }
 
+// SetStrict set strict mode for loading, it is off by default
+// when it is on, any data other than whitespace after the json value is an error
+func (j *Json) SetStrict(strict bool) {
+	j.strict = strict
+}
+
+// SetWeakType set weak type mode for lenient type coercion, it is off by default
+// when it is on, the value accessors convert the value as below:
+//   Bool:        "true", "false", "1", "0" and numbers (non-zero is true)
+//   String:      numbers and bools, formatted as in JSON
+//   Float64/Int/Int64/Uint64: numeric strings in json number format, true as 1 and false as 0
+//   Array/StringArray:        single non-null value as an array of one
+func (j *Json) SetWeakType(weak bool) {
+	j.weakType = weak
+}
+
 // Set set key-value to json object, dot(.) separated key is supported
 //   json.Set("status", 1)
 //   json.Set("status.code", 1)
 //   ! NOT SUPPORTED json.Set("result.intlist.3", 666)
 func (j *Json) Set(key string, value interface{}) {
 	key = strings.TrimSpace(key)
+	j.positions.forget(joinPath(j.path, key))
 	if key == "" {
 		j.data = value
+		if j.path == "" {
+			j.editSet(key, value)
+		}
 		return
 	}
 
-	result, err := j.Map()
-	if err != nil {
+	if !j.IsMap() {
 		return
 	}
 
+	result := j.data
 	keys := strings.Split(key, ".")
 	for i := 0; i < len(keys)-1; i++ {
 		v := strings.TrimSpace(keys[i])
 		if v != "" {
-			if _, ok := result[v]; !ok {
-				result[v] = make(map[string]interface{})
+			next, ok := objectGet(result, v)
+			if !ok {
+				next = j.newObject()
+				objectSet(result, v, next)
 			}
-			result = result[v].(map[string]interface{})
+			result = next
 		}
 	}
 
-	result[keys[len(keys)-1]] = value
+	if objectSet(result, keys[len(keys)-1], value) {
+		j.editSet(key, value)
+	}
 }
 
 // Del delete key-value from json object, dot(.) separated key is supported
@@ -195,28 +403,23 @@ func (j *Json) Set(key string, value interface{}) {
 //   json.Del("status.code")
 //   ! NOT SUPPORTED json.Del("result.intlist.3")
 func (j *Json) Del(key string) {
-	result, err := j.Map()
-	if err != nil {
-		return
-	}
+	j.positions.forget(joinPath(j.path, key))
 
-	var ok bool
+	result := j.data
 	keys := strings.Split(key, ".")
 	for i := 0; i < len(keys)-1; i++ {
 		v := strings.TrimSpace(keys[i])
 		if v != "" {
-			if _, ok = result[v]; !ok {
-				return
-			}
-			result, ok = result[v].(map[string]interface{})
+			next, ok := objectGet(result, v)
 			if !ok {
 				return
 			}
+			result = next
 		}
 	}
 
-	if _, ok := result[keys[len(keys)-1]]; ok {
-		delete(result, keys[len(keys)-1])
+	if objectDel(result, keys[len(keys)-1]) {
+		j.editDel(key)
 	}
 }
 
@@ -231,9 +434,8 @@ func (j *Json) Has(key string) bool {
 	for i := 0; i < len(keys); i++ {
 		v := strings.TrimSpace(keys[i])
 		if v != "" {
-			tmp, err := result.Map()
-			if err == nil {
-				if _, ok := tmp[v]; !ok {
+			if result.IsMap() {
+				if _, ok := objectGet(result.data, v); !ok {
 					return false
 				}
 				if i == len(keys)-1 {
@@ -241,13 +443,13 @@ func (j *Json) Has(key string) bool {
 				}
 				result = result.Get(v)
 			} else {
-				tmp, err := result.Array()
-				if err == nil {
+				tmp, ok := result.data.([]interface{})
+				if ok {
 					n, err := strconv.Atoi(v)
 					if err != nil {
 						return false
 					}
-					if n >= len(tmp) {
+					if n < 0 || n >= len(tmp) {
 						return false
 					}
 					if i == len(keys)-1 {
@@ -274,23 +476,21 @@ func (j *Json) Get(key string) *Json {
 	for _, v := range strings.Split(key, ".") {
 		v = strings.TrimSpace(v)
 		if v != "" {
-			tmp, err := result.Map()
-			if err == nil {
-				if _, ok := tmp[v]; ok {
-					result = &Json{tmp[v], j.escapeHtml}
+			if result.IsMap() {
+				if value, ok := objectGet(result.data, v); ok {
+					result = result.child(value, v)
 				} else {
-					return &Json{nil, j.escapeHtml}
+					return j.child(nil, key)
 				}
 			} else {
-				_, err := result.Array()
-				if err == nil {
+				if result.IsArray() {
 					i, err := strconv.Atoi(v)
 					if err != nil {
-						return &Json{nil, j.escapeHtml}
+						return j.child(nil, key)
 					}
 					result = result.Index(i)
 				} else {
-					return &Json{nil, j.escapeHtml}
+					return j.child(nil, key)
 				}
 			}
 		}
@@ -302,37 +502,67 @@ func (j *Json) Get(key string) *Json {
 // Index returns a pointer to the index of json object
 //   json.Get("int_list").Index(1).Int()
 func (j *Json) Index(i int) *Json {
-	data, err := j.Array()
-	if err == nil {
-		if len(data) > i {
-			return &Json{data[i], j.escapeHtml}
+	data, ok := j.data.([]interface{})
+	if ok {
+		if i >= 0 && len(data) > i {
+			return j.child(data[i], strconv.Itoa(i))
 		}
 	}
 
-	return &Json{nil, j.escapeHtml}
+	return j.child(nil, strconv.Itoa(i))
 }
 
-// Len returns len of json object, -1 if type invalid or error
-func (j *Json) Len() int {
-	if v, err := j.Map(); err == nil {
-		return len(v)
+// Path returns the dot(.) separated path of json object from where it was loaded
+//   json.Get("result").Get("intlist").Index(3).Path() // result.intlist.3
+func (j *Json) Path() string {
+	return j.path
+}
+
+// child returns a new json object of data at key, with the same settings as j
+func (j *Json) child(data interface{}, key string) *Json {
+	c := *j
+	c.data = data
+	c.path = joinPath(j.path, key)
+	return &c
+}
+
+// joinPath returns path joined with the dot(.) separated key
+func joinPath(path, key string) string {
+	keys := []string{}
+	if path != "" {
+		keys = append(keys, path)
 	}
 
-	if v, err := j.Array(); err == nil {
-		return len(v)
+	for _, v := range strings.Split(key, ".") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			keys = append(keys, v)
+		}
 	}
 
-	if v, err := j.String(); err == nil {
+	return strings.Join(keys, ".")
+}
+
+// Len returns len of json object, -1 if type invalid or error
+func (j *Json) Len() int {
+	switch v := j.data.(type) {
+	case map[string]interface{}:
+		return len(v)
+	case *Object:
+		return v.Len()
+	case []interface{}:
+		return len(v)
+	case string:
 		return len(v)
+	default:
+		return -1
 	}
-
-	return -1
 }
 
 // IsMap returns json object is a map
 func (j *Json) IsMap() bool {
 	switch j.data.(type) {
-	case map[string]interface{}:
+	case map[string]interface{}, *Object:
 		return true
 	default:
 		return false
@@ -349,8 +579,12 @@ func (j *Json) IsArray() bool {
 	}
 }
 
-// Map returns as map from json object
+// Map returns as map from json object, a copy is returned for *Object, use Set and Del to change it
 func (j *Json) Map() (result map[string]interface{}, err error) {
+	if o, ok := j.data.(*Object); ok {
+		return o.Map(), nil
+	}
+
 	result, ok := (j.data).(map[string]interface{})
 	if !ok {
 		err = errors.New("assert to map failed")
@@ -362,6 +596,9 @@ func (j *Json) Map() (result map[string]interface{}, err error) {
 func (j *Json) Array() (result []interface{}, err error) {
 	result, ok := (j.data).([]interface{})
 	if !ok {
+		if j.weakType && j.data != nil && !j.IsMap() {
+			return []interface{}{j.data}, nil
+		}
 		err = errors.New("assert to array failed")
 	}
 	return
@@ -371,6 +608,9 @@ func (j *Json) Array() (result []interface{}, err error) {
 func (j *Json) Bool() (result bool, err error) {
 	result, ok := (j.data).(bool)
 	if !ok {
+		if j.weakType {
+			return weakBool(j.data)
+		}
 		err = errors.New("assert to bool failed")
 	}
 	return
@@ -380,6 +620,9 @@ func (j *Json) Bool() (result bool, err error) {
 func (j *Json) String() (result string, err error) {
 	result, ok := (j.data).(string)
 	if !ok {
+		if j.weakType {
+			return weakString(j.data)
+		}
 		err = errors.New("assert to string failed")
 	}
 	return
@@ -398,8 +641,15 @@ func (j *Json) StringArray() (result []string, err error) {
 		} else {
 			r, ok := v.(string)
 			if !ok {
-				err = errors.New("assert to []string failed")
-				return
+				if !j.weakType {
+					err = errors.New("assert to []string failed")
+					return
+				}
+				r, err = weakString(v)
+				if err != nil {
+					err = errors.New("assert to []string failed")
+					return
+				}
 			}
 			result = append(result, r)
 		}
@@ -438,15 +688,27 @@ func (j *Json) Time(args ...string) (result time.Time, err error) {
 
 // Float64 returns as float64 from json object
 func (j *Json) Float64() (result float64, err error) {
-	switch j.data.(type) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
 	case json.Number:
-		return j.data.(json.Number).Float64()
+		return data.(json.Number).Float64()
+	case string:
+		if j.nonFinite == NonFiniteString {
+			if f, ok := parseNonFinite(data.(string)); ok {
+				return f, nil
+			}
+		}
+		return 0, errors.New("invalid value type")
 	case float32, float64:
-		return reflect.ValueOf(j.data).Float(), nil
+		return reflect.ValueOf(data).Float(), nil
 	case int, int8, int16, int32, int64:
-		return float64(reflect.ValueOf(j.data).Int()), nil
+		return float64(reflect.ValueOf(data).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return float64(reflect.ValueOf(j.data).Uint()), nil
+		return float64(reflect.ValueOf(data).Uint()), nil
 	default:
 		return 0, errors.New("invalid value type")
 	}
@@ -454,16 +716,21 @@ func (j *Json) Float64() (result float64, err error) {
 
 // Int returns as int from json object
 func (j *Json) Int() (result int, err error) {
-	switch j.data.(type) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
 	case json.Number:
-		r, err := j.data.(json.Number).Int64()
+		r, err := data.(json.Number).Int64()
 		return int(r), err
 	case float32, float64:
-		return int(reflect.ValueOf(j.data).Float()), nil
+		return int(reflect.ValueOf(data).Float()), nil
 	case int, int8, int16, int32, int64:
-		return int(reflect.ValueOf(j.data).Int()), nil
+		return int(reflect.ValueOf(data).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return int(reflect.ValueOf(j.data).Uint()), nil
+		return int(reflect.ValueOf(data).Uint()), nil
 	default:
 		return 0, errors.New("invalid value type")
 	}
@@ -471,15 +738,20 @@ func (j *Json) Int() (result int, err error) {
 
 // Int64 returns as int64 from json object
 func (j *Json) Int64() (result int64, err error) {
-	switch j.data.(type) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
 	case json.Number:
-		return j.data.(json.Number).Int64()
+		return data.(json.Number).Int64()
 	case float32, float64:
-		return int64(reflect.ValueOf(j.data).Float()), nil
+		return int64(reflect.ValueOf(data).Float()), nil
 	case int, int8, int16, int32, int64:
-		return reflect.ValueOf(j.data).Int(), nil
+		return reflect.ValueOf(data).Int(), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return int64(reflect.ValueOf(j.data).Uint()), nil
+		return int64(reflect.ValueOf(data).Uint()), nil
 	default:
 		return 0, errors.New("invalid value type")
 	}
@@ -487,15 +759,20 @@ func (j *Json) Int64() (result int64, err error) {
 
 // Uint64 returns as uint64 from json object
 func (j *Json) Uint64() (result uint64, err error) {
-	switch j.data.(type) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
 	case json.Number:
-		return strconv.ParseUint(j.data.(json.Number).String(), 10, 64)
+		return strconv.ParseUint(data.(json.Number).String(), 10, 64)
 	case float32, float64:
-		return uint64(reflect.ValueOf(j.data).Float()), nil
+		return uint64(reflect.ValueOf(data).Float()), nil
 	case int, int8, int16, int32, int64:
-		return uint64(reflect.ValueOf(j.data).Int()), nil
+		return uint64(reflect.ValueOf(data).Int()), nil
 	case uint, uint8, uint16, uint32, uint64:
-		return reflect.ValueOf(j.data).Uint(), nil
+		return reflect.ValueOf(data).Uint(), nil
 	default:
 		return 0, errors.New("invalid value type")
 	}
@@ -505,7 +782,7 @@ func (j *Json) Uint64() (result uint64, err error) {
 // if error return default(if set) or panic
 func (j *Json) MustMap(args ...map[string]interface{}) map[string]interface{} {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustMap", ErrTooManyArguments))
 	}
 
 	r, err := j.Map()
@@ -517,14 +794,14 @@ func (j *Json) MustMap(args ...map[string]interface{}) map[string]interface{} {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustMap", err))
 }
 
 // MustArray returns as array from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustArray(args ...[]interface{}) []interface{} {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustArray", ErrTooManyArguments))
 	}
 
 	r, err := j.Array()
@@ -536,14 +813,14 @@ func (j *Json) MustArray(args ...[]interface{}) []interface{} {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustArray", err))
 }
 
 // MustBool returns as bool from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustBool(args ...bool) bool {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustBool", ErrTooManyArguments))
 	}
 
 	r, err := j.Bool()
@@ -555,14 +832,14 @@ func (j *Json) MustBool(args ...bool) bool {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustBool", err))
 }
 
 // MustString returns as string from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustString(args ...string) string {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustString", ErrTooManyArguments))
 	}
 
 	r, err := j.String()
@@ -574,14 +851,14 @@ func (j *Json) MustString(args ...string) string {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustString", err))
 }
 
 // MustStringArray returns as string from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustStringArray(args ...[]string) []string {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustStringArray", ErrTooManyArguments))
 	}
 
 	r, err := j.StringArray()
@@ -593,7 +870,7 @@ func (j *Json) MustStringArray(args ...[]string) []string {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustStringArray", err))
 }
 
 // MustTime returns as time.Time from json object
@@ -604,7 +881,7 @@ func (j *Json) MustStringArray(args ...[]string) []string {
 //   json.Time("2006-01-02 15:04:05", time.Unix(1548907870, 0))  // Has format, Has default
 func (j *Json) MustTime(args ...interface{}) time.Time {
 	if len(args) > 2 {
-		panic("Too many arguments")
+		panic(j.pathError("MustTime", ErrTooManyArguments))
 	}
 
 	format := ""
@@ -619,7 +896,7 @@ func (j *Json) MustTime(args ...interface{}) time.Time {
 			defbak = args[i].(time.Time)
 			defset = true
 		default:
-			panic("Invalid argument type")
+			panic(j.pathError("MustTime", ErrInvalidArgument))
 		}
 	}
 
@@ -639,14 +916,14 @@ func (j *Json) MustTime(args ...interface{}) time.Time {
 		return defbak
 	}
 
-	panic(err)
+	panic(j.pathError("MustTime", err))
 }
 
 // MustFloat64 returns as float64 from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustFloat64(args ...float64) float64 {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustFloat64", ErrTooManyArguments))
 	}
 
 	r, err := j.Float64()
@@ -658,14 +935,14 @@ func (j *Json) MustFloat64(args ...float64) float64 {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustFloat64", err))
 }
 
 // MustInt returns as int from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustInt(args ...int) int {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustInt", ErrTooManyArguments))
 	}
 
 	r, err := j.Int()
@@ -677,14 +954,14 @@ func (j *Json) MustInt(args ...int) int {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustInt", err))
 }
 
 // MustInt64 returns as int64 from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustInt64(args ...int64) int64 {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustInt64", ErrTooManyArguments))
 	}
 
 	r, err := j.Int64()
@@ -696,14 +973,14 @@ func (j *Json) MustInt64(args ...int64) int64 {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustInt64", err))
 }
 
 // MustUint64 returns as uint64 from json object with optional default value
 // if error return default(if set) or panic
 func (j *Json) MustUint64(args ...uint64) uint64 {
 	if len(args) > 1 {
-		panic("Too many arguments")
+		panic(j.pathError("MustUint64", ErrTooManyArguments))
 	}
 
 	r, err := j.Uint64()
@@ -715,5 +992,98 @@ func (j *Json) MustUint64(args ...uint64) uint64 {
 		return args[0]
 	}
 
-	panic(err)
+	panic(j.pathError("MustUint64", err))
+}
+
+// weakBool returns bool converted from bool-like string or number
+func weakBool(data interface{}) (bool, error) {
+	switch data.(type) {
+	case string:
+		switch strings.TrimSpace(data.(string)) {
+		case "true", "1":
+			return true, nil
+		case "false", "0":
+			return false, nil
+		default:
+			return false, errors.New("assert to bool failed")
+		}
+	case json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		r, err := (&Json{data: data}).Float64()
+		return r != 0, err
+	default:
+		return false, errors.New("assert to bool failed")
+	}
+}
+
+// weakString returns string converted from number or bool
+func weakString(data interface{}) (string, error) {
+	switch data.(type) {
+	case string:
+		return data.(string), nil
+	case json.Number:
+		return data.(json.Number).String(), nil
+	case bool:
+		return strconv.FormatBool(data.(bool)), nil
+	case float32, float64:
+		return formatFloat(reflect.ValueOf(data).Float(), reflect.TypeOf(data).Bits()), nil
+	case int, int8, int16, int32, int64:
+		return strconv.FormatInt(reflect.ValueOf(data).Int(), 10), nil
+	case uint, uint8, uint16, uint32, uint64:
+		return strconv.FormatUint(reflect.ValueOf(data).Uint(), 10), nil
+	default:
+		return "", errors.New("assert to string failed")
+	}
+}
+
+// formatFloat returns the float f of bits formatted as encoding/json,
+// in exponent format if it is less than 1e-6 or not less than 1e21
+func formatFloat(f float64, bits int) string {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	result := strconv.FormatFloat(f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(result)
+		if n >= 4 && result[n-4] == 'e' && result[n-3] == '-' && result[n-2] == '0' {
+			result = result[:n-2] + result[n-1:]
+		}
+	}
+
+	return result
+}
+
+// weakNumber returns json.Number converted from numeric string or bool
+func weakNumber(data interface{}) interface{} {
+	switch data.(type) {
+	case string:
+		if text := strings.TrimSpace(data.(string)); isNumber(text) {
+			return json.Number(text)
+		}
+		return data
+	case bool:
+		if data.(bool) {
+			return json.Number("1")
+		}
+		return json.Number("0")
+	default:
+		return data
+	}
+}
+
+// countReader counts the bytes read from reader
+type countReader struct {
+	reader io.Reader
+	n      int
+}
+
+// Read reads data from reader and counts it
+func (r *countReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.n += n
+	return
 }
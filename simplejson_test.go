@@ -20,9 +20,12 @@
 package simplejson
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -162,6 +165,102 @@ func Test_J_Load_Dump(t *testing.T) {
 	assert.Equal(t, code, 1)
 }
 
+func Test_Load_Dump_Reader_Writer(t *testing.T) {
+	// Load json from reader
+	j, err := LoadReader(strings.NewReader(textResult))
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("status.code").MustInt(), 1)
+
+	_, err = LoadReader(strings.NewReader(`{"status":`))
+	assert.NotNil(t, err)
+
+	// Load json from bytes
+	j, err = LoadBytes([]byte(textResult))
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("status.message").MustString(), "success")
+
+	_, err = LoadBytes(nil)
+	assert.NotNil(t, err)
+
+	// Dump json to bytes
+	b, err := DumpBytes(jsonResult)
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), textResult)
+
+	b, err = j.DumpBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), textResult)
+
+	_, err = DumpBytes(make(chan int))
+	assert.NotNil(t, err)
+
+	// Write json to writer
+	var buf bytes.Buffer
+	n, err := j.WriteTo(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, n, int64(len(textResult)))
+	assert.Equal(t, buf.String(), textResult)
+
+	_, err = New(make(chan int)).WriteTo(&buf)
+	assert.NotNil(t, err)
+
+	// Write and load with pipe
+	r, w := io.Pipe()
+	go func() {
+		_, err := New(jsonResult).WriteTo(w)
+		w.CloseWithError(err)
+	}()
+	j, err = LoadReader(r)
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("result.intlist.4").MustInt(), 4)
+}
+
+func Test_Loads_All_Strict(t *testing.T) {
+	// Loads only the first value
+	j, err := Loads(`{"id":1} {"id":2}`)
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("id").MustInt(), 1)
+
+	// Loads all values
+	js, err := LoadsAll(`{"id":1} {"id":2}` + "\n[3]4\"5\"")
+	assert.Nil(t, err)
+	assert.Len(t, js, 5)
+	assert.Equal(t, js[1].Get("id").MustInt(), 2)
+	assert.Equal(t, js[2].Index(0).MustInt(), 3)
+	assert.Equal(t, js[3].MustInt(), 4)
+	assert.Equal(t, js[4].MustString(), "5")
+
+	js, err = LoadsAll(" ")
+	assert.Nil(t, err)
+	assert.Len(t, js, 0)
+
+	js, err = LoadsAll(`{"id":1} {"id":`)
+	assert.NotNil(t, err)
+	assert.Len(t, js, 1)
+	assert.Equal(t, err.Error(), "simplejson: 1:16: unexpected end of JSON input")
+
+	js, err = LoadsAll("{\"id\":1}\n{\"id\": x}")
+	assert.Len(t, js, 1)
+	assert.Equal(t, err.(*SyntaxError).Line, 2)
+	assert.Equal(t, err.(*SyntaxError).Column, 8)
+	assert.Equal(t, err.(*SyntaxError).Snippet, "{\"id\": x}\n       ^")
+
+	// Strict mode
+	j = New()
+	j.SetStrict(true)
+	err = j.Loads(" {\"id\":1} \n")
+	assert.Nil(t, err)
+	assert.Equal(t, j.Get("id").MustInt(), 1)
+
+	for _, v := range []string{`{"id":1} {"id":2}`, `{"id":1}}`, `{"id":1} x`, `1 2`} {
+		err = j.Loads(v)
+		assert.NotNil(t, err, v)
+	}
+
+	err = j.Loads(`{"id":`)
+	assert.NotNil(t, err)
+}
+
 func Test_Set_Has_Get_Del(t *testing.T) {
 	defer os.Remove(textFile)
 
@@ -725,6 +824,96 @@ func Test_Get_Must_Assert_Data_W_Default(t *testing.T) {
 	assert.Equal(t, stringArrayData, []string{"i", "am", "ok"})
 }
 
+func Test_Weak_Type(t *testing.T) {
+	jsonData, err := Loads(`{"int":"123","float":" 1.5 ","bool":"true","num":1,"zero":0,"on":true,"str":"a","list":["a",1,true,null]}`)
+	assert.Nil(t, err)
+
+	// Strict by default
+	_, err = jsonData.Get("int").Int()
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("num").Bool()
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("num").String()
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("str").Array()
+	assert.NotNil(t, err)
+
+	jsonData.SetWeakType(true)
+
+	// Numbers from string and bool
+	assert.Equal(t, jsonData.Get("int").MustInt(), 123)
+	assert.Equal(t, jsonData.Get("int").MustInt64(), int64(123))
+	assert.Equal(t, jsonData.Get("int").MustUint64(), uint64(123))
+	assert.Equal(t, jsonData.Get("float").MustFloat64(), 1.5)
+	assert.Equal(t, jsonData.Get("on").MustInt(), 1)
+	_, err = jsonData.Get("str").Int()
+	assert.NotNil(t, err)
+
+	// Only the strings of json number are numeric
+	for _, v := range []string{"inf", "NaN", "Infinity", "1_0", "0x1p4", "+5", "01", ".5", "1.", "", "1e"} {
+		jsonData.Set("weak", v)
+		_, err = jsonData.Get("weak").Float64()
+		assert.NotNil(t, err, v)
+		_, err = jsonData.Get("weak").Int()
+		assert.NotNil(t, err, v)
+		_, err = jsonData.Get("weak").Uint64()
+		assert.NotNil(t, err, v)
+		_, err = jsonData.Get("weak").Number()
+		assert.NotNil(t, err, v)
+		_, err = jsonData.Get("weak").Bool()
+		assert.NotNil(t, err, v)
+	}
+	jsonData.Set("weak", " -1.5e1 ")
+	assert.Equal(t, jsonData.Get("weak").MustFloat64(), -15.0)
+	assert.Equal(t, jsonData.Get("weak").MustNumber(), "-1.5e1")
+
+	// Bool from string and number
+	assert.True(t, jsonData.Get("bool").MustBool())
+	assert.True(t, jsonData.Get("num").MustBool())
+	assert.False(t, jsonData.Get("zero").MustBool())
+	_, err = jsonData.Get("str").Bool()
+	assert.NotNil(t, err)
+	for _, v := range []string{"t", "T", "TRUE", "True", "f", "FALSE", "yes"} {
+		jsonData.Set("weak", v)
+		_, err = jsonData.Get("weak").Bool()
+		assert.NotNil(t, err, v)
+	}
+	jsonData.Set("weak", " 0 ")
+	assert.False(t, jsonData.Get("weak").MustBool(true))
+	_, err = jsonData.Get("list").Bool()
+	assert.NotNil(t, err)
+
+	// String from number and bool
+	assert.Equal(t, jsonData.Get("num").MustString(), "1")
+	assert.Equal(t, jsonData.Get("on").MustString(), "true")
+	jsonData.Set("pi", 3.14)
+	assert.Equal(t, jsonData.Get("pi").MustString(), "3.14")
+	jsonData.Set("uint", uint8(8))
+	assert.Equal(t, jsonData.Get("uint").MustString(), "8")
+	for _, v := range []interface{}{1e21, 1e20, 1e-7, 0.000001, -1.5e300, float32(1e21), float32(0.1)} {
+		expect, _ := json.Marshal(v)
+		jsonData.Set("float", v)
+		assert.Equal(t, jsonData.Get("float").MustString(), string(expect))
+	}
+	_, err = jsonData.Get("list").String()
+	assert.NotNil(t, err)
+
+	// Array from single value
+	assert.Equal(t, jsonData.Get("str").MustArray(), []interface{}{"a"})
+	assert.Equal(t, jsonData.Get("str").MustStringArray(), []string{"a"})
+	assert.Equal(t, jsonData.Get("list").MustStringArray(), []string{"a", "1", "true", ""})
+	_, err = jsonData.Get("not-exists").Array()
+	assert.NotNil(t, err)
+	_, err = jsonData.Array()
+	assert.NotNil(t, err)
+
+	// Structure is not coerced
+	assert.Equal(t, jsonData.Get("str").Len(), 1)
+	assert.Equal(t, jsonData.Get("num").Len(), -1)
+	assert.False(t, jsonData.Has("str.0"))
+	assert.Nil(t, jsonData.Get("str.0").data)
+}
+
 func Test_HTML_Escape(t *testing.T) {
 	// Init json and set html
 	jsonData := New()
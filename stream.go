@@ -0,0 +1,268 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// streamer walks json tokens from a decoder without loading the whole json
+type streamer struct {
+	dec *json.Decoder
+}
+
+// Extract reads json from r, calls fn with every value at paths, without loading the whole json
+// path is dot(.) separated key as Get, and "*" matches any key of map or index of array,
+// value is passed with its path, return error in fn to stop the extracting
+//   Extract(r, []string{"meta.count", "items.*.id"}, func(path string, value *Json) error {
+//       fmt.Println(path, value.MustInt())
+//       return nil
+//   })
+func Extract(r io.Reader, paths []string, fn func(path string, value *Json) error) error {
+	patterns := splitPaths(paths)
+
+	s := newStreamer(r)
+	return s.walk([]string{}, patterns, func(keys []string) error {
+		var data interface{}
+		err := s.dec.Decode(&data)
+		if err != nil {
+			return err
+		}
+		return extractValue(data, keys, patterns, fn)
+	})
+}
+
+// StreamArray reads json from r, calls fn with every item of the array at path one by one,
+// without loading the whole json, so a huge array could be processed with constant memory
+// path is dot(.) separated key as Get, "" for the top level array, return error in fn to stop
+//   StreamArray(r, "result.items", func(i int, item *Json) error {
+//       fmt.Println(i, item.Get("id").MustInt())
+//       return nil
+//   })
+func StreamArray(r io.Reader, path string, fn func(i int, item *Json) error) error {
+	found := false
+
+	s := newStreamer(r)
+	err := s.walk([]string{}, splitPaths([]string{path}), func(keys []string) error {
+		found = true
+		t, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		if t != json.Delim('[') {
+			return errors.New("value at path is not an array")
+		}
+		for i := 0; s.dec.More(); i++ {
+			var data interface{}
+			err := s.dec.Decode(&data)
+			if err != nil {
+				return err
+			}
+			j := New(data)
+			j.path = joinPath(strings.Join(keys, "."), strconv.Itoa(i))
+			err = fn(i, j)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = s.dec.Token()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("array at path not found")
+	}
+
+	return nil
+}
+
+// newStreamer returns a new streamer reading from r
+func newStreamer(r io.Reader) *streamer {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	return &streamer{
+		dec: dec,
+	}
+}
+
+// walk walks the next json value at keys, calls match if keys matches any of patterns,
+// goes into the value if keys is prefix of any of patterns, otherwise skips the value
+// match must consume the whole value
+func (s *streamer) walk(keys []string, patterns [][]string, match func(keys []string) error) error {
+	exact, prefix := matchPaths(keys, patterns)
+	if exact {
+		return match(keys)
+	}
+
+	if !prefix {
+		return s.skip()
+	}
+
+	t, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		for s.dec.More() {
+			k, err := s.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return errors.New("invalid object key")
+			}
+			err = s.walk(append(keys[:len(keys):len(keys)], key), patterns, match)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = s.dec.Token()
+	case json.Delim('['):
+		for i := 0; s.dec.More(); i++ {
+			err = s.walk(append(keys[:len(keys):len(keys)], strconv.Itoa(i)), patterns, match)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = s.dec.Token()
+	}
+
+	return err
+}
+
+// skip skips the next json value
+func (s *streamer) skip() error {
+	depth := 0
+	for {
+		t, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// extractValue calls fn with data and its children at keys matching any of patterns
+func extractValue(data interface{}, keys []string, patterns [][]string, fn func(string, *Json) error) error {
+	exact, prefix := matchPaths(keys, patterns)
+	if exact {
+		j := New(data)
+		j.path = strings.Join(keys, ".")
+		err := fn(j.path, j)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !prefix {
+		return nil
+	}
+
+	switch data.(type) {
+	case map[string]interface{}:
+		m := data.(map[string]interface{})
+		ks := make([]string, 0, len(m))
+		for k := range m {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+		for _, k := range ks {
+			err := extractValue(m[k], append(keys[:len(keys):len(keys)], k), patterns, fn)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, v := range data.([]interface{}) {
+			err := extractValue(v, append(keys[:len(keys):len(keys)], strconv.Itoa(i)), patterns, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitPaths returns paths split into keys
+func splitPaths(paths []string) [][]string {
+	result := [][]string{}
+	for _, v := range paths {
+		path := joinPath("", v)
+		if path == "" {
+			result = append(result, []string{})
+		} else {
+			result = append(result, strings.Split(path, "."))
+		}
+	}
+
+	return result
+}
+
+// matchPaths returns whether keys matches any of patterns, and whether keys is prefix of any of patterns
+func matchPaths(keys []string, patterns [][]string) (exact, prefix bool) {
+	for _, p := range patterns {
+		if len(p) < len(keys) || !matchKeys(keys, p[:len(keys)]) {
+			continue
+		}
+		if len(p) == len(keys) {
+			exact = true
+		} else {
+			prefix = true
+		}
+	}
+
+	return
+}
+
+// matchKeys returns whether keys matches pattern, "*" in pattern matches any key
+func matchKeys(keys []string, pattern []string) bool {
+	if len(keys) != len(pattern) {
+		return false
+	}
+
+	for i, k := range keys {
+		if pattern[i] != "*" && pattern[i] != k {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+var textStream = `{
+	"meta": {"count": 3, "next": null},
+	"items": [
+		{"id": 1, "tags": ["a", "b"], "more": {"x": [1, 2, {"y": 3}]}},
+		{"id": 2, "tags": []},
+		{"name": "no-id"},
+		{"id": 4}
+	],
+	"tail": "end"
+}`
+
+func Test_Extract(t *testing.T) {
+	paths := []string{}
+	values := []interface{}{}
+	fn := func(path string, value *Json) error {
+		assert.Equal(t, value.Path(), path)
+		paths = append(paths, path)
+		if value.IsMap() || value.IsArray() {
+			values = append(values, value.Len())
+		} else {
+			s, _ := value.Dumps()
+			values = append(values, s)
+		}
+		return nil
+	}
+
+	// Extract by path and wildcard
+	err := Extract(strings.NewReader(textStream), []string{"meta.count", "items.*.id", "tail"}, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, paths, []string{"meta.count", "items.0.id", "items.1.id", "items.3.id", "tail"})
+	assert.Equal(t, values, []interface{}{"3", "1", "2", "4", `"end"`})
+
+	// Extract container and its children
+	paths = paths[:0]
+	values = values[:0]
+	err = Extract(strings.NewReader(textStream), []string{"items.0.more", "items.0.more.x.*", "items.*.tags.1"}, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, paths, []string{"items.0.tags.1", "items.0.more", "items.0.more.x.0", "items.0.more.x.1", "items.0.more.x.2"})
+	assert.Equal(t, values, []interface{}{`"b"`, 1, "1", "2", 1})
+
+	// Extract from nested map in decoded value
+	paths = paths[:0]
+	values = values[:0]
+	err = Extract(strings.NewReader(textStream), []string{"meta", "meta.*"}, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, paths, []string{"meta", "meta.count", "meta.next"})
+
+	// Extract the root
+	paths = paths[:0]
+	values = values[:0]
+	err = Extract(strings.NewReader(textStream), []string{""}, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, paths, []string{""})
+	assert.Equal(t, values, []interface{}{3})
+
+	// Not matched
+	paths = paths[:0]
+	err = Extract(strings.NewReader(textStream), []string{"not-exists", "items.x.id", "tail.x"}, fn)
+	assert.Nil(t, err)
+	assert.Len(t, paths, 0)
+
+	// Stop by error
+	stop := errors.New("stop")
+	n := 0
+	err = Extract(strings.NewReader(textStream), []string{"items.*.id"}, func(path string, value *Json) error {
+		n++
+		return stop
+	})
+	assert.Equal(t, err, stop)
+	assert.Equal(t, n, 1)
+
+	n = 0
+	err = Extract(strings.NewReader(textStream), []string{"meta", "meta.count"}, func(path string, value *Json) error {
+		n++
+		if n == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, err, stop)
+
+	err = Extract(strings.NewReader(textStream), []string{"items", "items.*.tags.*"}, func(path string, value *Json) error {
+		if path == "items.0.tags.1" {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, err, stop)
+
+	// Invalid json
+	for _, v := range []string{`{"items": [1, 2`, `{"items": [{"id": }]}`, `{"a": {"b": 1`, `{"a": [1, `, ``} {
+		err = Extract(strings.NewReader(v), []string{"items.*.id", "a.c"}, fn)
+		assert.NotNil(t, err, v)
+	}
+}
+
+func Test_Stream_Array(t *testing.T) {
+	// Nested array
+	ids := []int{}
+	err := StreamArray(strings.NewReader(textStream), "items", func(i int, item *Json) error {
+		assert.Equal(t, item.Path(), "items."+strconv.Itoa(i))
+		ids = append(ids, item.Get("id").MustInt(-1))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ids, []int{1, 2, -1, 4})
+
+	// Top level array
+	n := 0
+	err = StreamArray(strings.NewReader(`[{"id": 1}, [2], 3, "4", null]`), "", func(i int, item *Json) error {
+		assert.Equal(t, i, n)
+		assert.Equal(t, item.Path(), strconv.Itoa(i))
+		n++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, n, 5)
+
+	// Empty array
+	err = StreamArray(strings.NewReader(`{"items": []}`), "items", func(i int, item *Json) error {
+		return errors.New("unreachable")
+	})
+	assert.Nil(t, err)
+
+	// Array in array
+	tags := []string{}
+	err = StreamArray(strings.NewReader(textStream), "items.0.tags", func(i int, item *Json) error {
+		tags = append(tags, item.MustString())
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, tags, []string{"a", "b"})
+
+	// Stop by error
+	stop := errors.New("stop")
+	err = StreamArray(strings.NewReader(textStream), "items", func(i int, item *Json) error {
+		return stop
+	})
+	assert.Equal(t, err, stop)
+
+	// Not found or not array
+	for _, v := range []string{"not-exists", "meta", "tail", "items.0.id"} {
+		err = StreamArray(strings.NewReader(textStream), v, func(i int, item *Json) error {
+			return nil
+		})
+		assert.NotNil(t, err, v)
+	}
+
+	// Invalid json
+	for _, v := range []string{`[1, 2`, `[1, }`, `{"items": [{"id": }]}`, ``} {
+		err = StreamArray(strings.NewReader(v), "", func(i int, item *Json) error {
+			return nil
+		})
+		assert.NotNil(t, err, v)
+	}
+}
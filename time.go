@@ -0,0 +1,233 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// EpochUnit is the unit of numeric epoch time
+type EpochUnit int
+
+// EpochUnit values, EpochAuto detects the unit by the magnitude of value
+const (
+	EpochAuto EpochUnit = iota
+	EpochSecond
+	EpochMillisecond
+	EpochMicrosecond
+	EpochNanosecond
+)
+
+// TimeFormat is the format for parsing and setting time
+//   Layouts:  the layouts to try in order, if empty the default is
+//             time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"
+//   Location: the location of time without zone, time.Local if nil
+//   Unit:     the unit of numeric epoch time, EpochAuto if not set
+type TimeFormat struct {
+	Layouts  []string
+	Location *time.Location
+	Unit     EpochUnit
+}
+
+// defaultTimeLayouts is the layouts to try if TimeFormat.Layouts is empty
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// TimeWith returns as time.Time from json object using the format
+// string value is parsed with layouts in order, number value is parsed as epoch time
+//   json.TimeWith(TimeFormat{})
+//   json.TimeWith(TimeFormat{Layouts: []string{"2006-01-02", "01/02/2006"}})
+//   json.TimeWith(TimeFormat{Unit: EpochMillisecond, Location: time.UTC})
+func (j *Json) TimeWith(format TimeFormat) (result time.Time, err error) {
+	loc := format.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	switch j.data.(type) {
+	case string:
+		text := strings.TrimSpace(j.data.(string))
+		layouts := format.Layouts
+		if len(layouts) == 0 {
+			layouts = defaultTimeLayouts
+		}
+		for _, v := range layouts {
+			r, e := time.ParseInLocation(v, text, loc)
+			if e == nil {
+				return r, nil
+			}
+			if err == nil {
+				err = e
+			}
+		}
+		if !j.weakType {
+			return
+		}
+		n, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return
+		}
+		return epochTime(n, format.Unit, loc)
+	default:
+		n, e := j.epochRat()
+		if e != nil {
+			return result, e
+		}
+		return epochTime(n, format.Unit, loc)
+	}
+}
+
+// MustTimeWith returns as time.Time from json object using the format with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustTimeWith(format TimeFormat, args ...time.Time) time.Time {
+	if len(args) > 1 {
+		panic(j.pathError("MustTimeWith", ErrTooManyArguments))
+	}
+
+	r, err := j.TimeWith(format)
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustTimeWith", err))
+}
+
+// SetTime set key-time to json object using the format, dot(.) separated key is supported
+// time is set as string of the first layout if Layouts is set, or as number if Unit is set,
+// otherwise as string of time.RFC3339Nano, Location is the location to convert time to
+//   json.SetTime("created", time.Now(), TimeFormat{})
+//   json.SetTime("created", time.Now(), TimeFormat{Layouts: []string{"2006-01-02"}})
+//   json.SetTime("created", time.Now(), TimeFormat{Unit: EpochMillisecond})
+func (j *Json) SetTime(key string, t time.Time, format TimeFormat) {
+	if format.Location != nil {
+		t = t.In(format.Location)
+	}
+
+	switch {
+	case len(format.Layouts) > 0:
+		j.Set(key, t.Format(format.Layouts[0]))
+	case epochUnitNanos(format.Unit) > 0:
+		n := new(big.Int).SetInt64(t.Unix())
+		n.Mul(n, big.NewInt(int64(time.Second)))
+		n.Add(n, big.NewInt(int64(t.Nanosecond())))
+		n.Quo(n, big.NewInt(epochUnitNanos(format.Unit)))
+		j.Set(key, n.Int64())
+	default:
+		j.Set(key, t.Format(time.RFC3339Nano))
+	}
+}
+
+// epochRat returns the number of json object as big.Rat
+func (j *Json) epochRat() (*big.Rat, error) {
+	data := j.data
+	if j.weakType {
+		data = weakNumber(data)
+	}
+
+	switch data.(type) {
+	case json.Number:
+		r, ok := new(big.Rat).SetString(data.(json.Number).String())
+		if !ok {
+			return nil, errors.New("invalid number value")
+		}
+		return r, nil
+	case float32, float64:
+		f, _ := j.child(data, "").Float64()
+		r := new(big.Rat)
+		if r.SetFloat64(f) == nil {
+			return nil, errors.New("invalid number value")
+		}
+		return r, nil
+	case int, int8, int16, int32, int64:
+		r, _ := j.child(data, "").Int64()
+		return new(big.Rat).SetInt64(r), nil
+	case uint, uint8, uint16, uint32, uint64:
+		r, _ := j.child(data, "").Uint64()
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(r)), nil
+	default:
+		return nil, errors.New("invalid value type")
+	}
+}
+
+// epochTime returns the time of epoch number in unit
+func epochTime(n *big.Rat, unit EpochUnit, loc *time.Location) (time.Time, error) {
+	if unit == EpochAuto {
+		unit = epochDetect(n)
+	}
+
+	nanos := epochUnitNanos(unit)
+	if nanos == 0 {
+		return time.Time{}, errors.New("invalid epoch unit")
+	}
+
+	n = new(big.Rat).Mul(n, new(big.Rat).SetInt64(nanos))
+	ns := new(big.Int).Quo(n.Num(), n.Denom())
+
+	sec, nsec := new(big.Int).DivMod(ns, big.NewInt(int64(time.Second)), new(big.Int))
+	if !sec.IsInt64() {
+		return time.Time{}, errors.New("epoch time out of range")
+	}
+
+	return time.Unix(sec.Int64(), nsec.Int64()).In(loc), nil
+}
+
+// epochDetect returns the unit guessed by the magnitude of epoch number
+// less than 1e11 is seconds (until year 5138), then milliseconds, microseconds and nanoseconds
+func epochDetect(n *big.Rat) EpochUnit {
+	abs := new(big.Rat).Abs(n)
+	switch {
+	case abs.Cmp(new(big.Rat).SetFloat64(1e11)) < 0:
+		return EpochSecond
+	case abs.Cmp(new(big.Rat).SetFloat64(1e14)) < 0:
+		return EpochMillisecond
+	case abs.Cmp(new(big.Rat).SetFloat64(1e17)) < 0:
+		return EpochMicrosecond
+	default:
+		return EpochNanosecond
+	}
+}
+
+// epochUnitNanos returns nanoseconds of the epoch unit
+func epochUnitNanos(unit EpochUnit) int64 {
+	switch unit {
+	case EpochSecond:
+		return int64(time.Second)
+	case EpochMillisecond:
+		return int64(time.Millisecond)
+	case EpochMicrosecond:
+		return int64(time.Microsecond)
+	case EpochNanosecond:
+		return int64(time.Nanosecond)
+	default:
+		return 0
+	}
+}
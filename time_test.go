@@ -0,0 +1,155 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Time_With_Layouts(t *testing.T) {
+	jsonData := New()
+
+	// Default layouts
+	jsonData.Set("time", "2019-01-31T12:11:10.123456789+08:00")
+	timeData, err := jsonData.Get("time").TimeWith(TimeFormat{})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData.UnixNano(), int64(1548907870123456789))
+
+	jsonData.Set("time", "2019-01-31T12:11:10+08:00")
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData.Unix(), int64(1548907870))
+
+	jsonData.Set("time", "2019-01-31")
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{Location: time.UTC})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData, time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	jsonData.Set("time", "2019-01-31 12:11:10")
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData, time.Date(2019, 1, 31, 12, 11, 10, 0, time.Local))
+
+	// Custom layouts in order
+	layouts := []string{"2006-01-02", "01/02/2006"}
+	jsonData.Set("time", "01/31/2019")
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{Layouts: layouts, Location: time.UTC})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData, time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	// Location is used for time without zone
+	loc := time.FixedZone("UTC+8", 8*3600)
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{Layouts: layouts, Location: loc})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData.Unix(), int64(1548864000))
+
+	// No layout matched
+	jsonData.Set("time", "i-am-not-the-time")
+	_, err = jsonData.Get("time").TimeWith(TimeFormat{Layouts: layouts})
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("not-exists").TimeWith(TimeFormat{})
+	assert.NotNil(t, err)
+}
+
+func Test_Time_With_Epoch(t *testing.T) {
+	jsonData, err := Loads(`{"s":1548907870,"ms":1548907870123,"us":1548907870123456,"ns":1548907870123456789,"f":1548907870.5}`)
+	assert.Nil(t, err)
+
+	// Auto detected unit
+	for _, v := range []string{"s", "ms", "us", "ns"} {
+		timeData, err := jsonData.Get(v).TimeWith(TimeFormat{})
+		assert.Nil(t, err)
+		assert.Equal(t, timeData.Unix(), int64(1548907870))
+	}
+
+	timeData := jsonData.Get("ns").MustTimeWith(TimeFormat{})
+	assert.Equal(t, timeData.UnixNano(), int64(1548907870123456789))
+
+	// Fractional epoch
+	timeData = jsonData.Get("f").MustTimeWith(TimeFormat{})
+	assert.Equal(t, timeData.UnixNano(), int64(1548907870500000000))
+
+	jsonData.Set("float", float64(1548907870123.5))
+	timeData = jsonData.Get("float").MustTimeWith(TimeFormat{Unit: EpochMillisecond})
+	assert.Equal(t, timeData.UnixNano(), int64(1548907870123500000))
+
+	// Explicit unit
+	timeData = jsonData.Get("s").MustTimeWith(TimeFormat{Unit: EpochMillisecond})
+	assert.Equal(t, timeData.UnixNano(), int64(1548907870000000))
+
+	jsonData.Set("int", int(1548907870))
+	timeData = jsonData.Get("int").MustTimeWith(TimeFormat{Unit: EpochSecond, Location: time.UTC})
+	assert.Equal(t, timeData, time.Unix(1548907870, 0).UTC())
+
+	jsonData.Set("uint", uint64(1548907870))
+	timeData = jsonData.Get("uint").MustTimeWith(TimeFormat{Unit: EpochSecond})
+	assert.Equal(t, timeData.Unix(), int64(1548907870))
+
+	// Invalid unit
+	_, err = jsonData.Get("s").TimeWith(TimeFormat{Unit: EpochUnit(99)})
+	assert.NotNil(t, err)
+
+	// Numeric string needs weak type
+	jsonData.Set("text", "1548907870")
+	_, err = jsonData.Get("text").TimeWith(TimeFormat{})
+	assert.NotNil(t, err)
+	jsonData.SetWeakType(true)
+	timeData, err = jsonData.Get("text").TimeWith(TimeFormat{})
+	assert.Nil(t, err)
+	assert.Equal(t, timeData.Unix(), int64(1548907870))
+
+	// Must with default
+	defTime := time.Unix(1548907870, 0)
+	assert.Equal(t, jsonData.Get("not-exists").MustTimeWith(TimeFormat{}, defTime), defTime)
+	assert.Panic(t, func() { jsonData.Get("not-exists").MustTimeWith(TimeFormat{}) })
+	assert.Panic(t, func() { jsonData.Get("s").MustTimeWith(TimeFormat{}, defTime, defTime) })
+}
+
+func Test_Set_Time(t *testing.T) {
+	jsonData := New()
+	testTime := time.Date(2019, 1, 31, 12, 11, 10, 123000000, time.UTC)
+
+	// Default is RFC3339Nano
+	jsonData.SetTime("time", testTime, TimeFormat{})
+	assert.Equal(t, jsonData.Get("time").MustString(), "2019-01-31T12:11:10.123Z")
+
+	// Layout and location
+	format := TimeFormat{Layouts: []string{"2006-01-02 15:04:05"}, Location: time.FixedZone("UTC+8", 8*3600)}
+	jsonData.SetTime("time", testTime, format)
+	assert.Equal(t, jsonData.Get("time").MustString(), "2019-01-31 20:11:10")
+	timeData, err := jsonData.Get("time").TimeWith(format)
+	assert.Nil(t, err)
+	assert.True(t, timeData.Equal(testTime.Truncate(time.Second)))
+
+	// Epoch unit
+	jsonData.SetTime("time", testTime, TimeFormat{Unit: EpochMillisecond})
+	assert.Equal(t, jsonData.Get("time").MustInt64(), int64(1548936670123))
+	timeData, err = jsonData.Get("time").TimeWith(TimeFormat{Unit: EpochMillisecond})
+	assert.Nil(t, err)
+	assert.True(t, timeData.Equal(testTime))
+
+	jsonData.SetTime("a.b", testTime, TimeFormat{Unit: EpochSecond})
+	text, err := jsonData.Get("a").Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, text, `{"b":1548936670}`)
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+)
+
+// Bytes returns as []byte from json object, decoded from base64 string
+// both the standard and the URL-safe encoding, padded or not, are supported
+func (j *Json) Bytes() (result []byte, err error) {
+	if r, ok := j.data.([]byte); ok {
+		return r, nil
+	}
+
+	text, err := j.String()
+	if err != nil {
+		return
+	}
+
+	encodings := []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	}
+
+	for _, v := range encodings {
+		result, err = v.DecodeString(text)
+		if err == nil {
+			return
+		}
+	}
+
+	return nil, errors.New("assert to base64 bytes failed")
+}
+
+// URL returns as *url.URL from json object
+func (j *Json) URL() (result *url.URL, err error) {
+	text, err := j.String()
+	if err != nil {
+		return
+	}
+
+	return url.Parse(text)
+}
+
+// IP returns as net.IP from json object, both IPv4 and IPv6 are supported
+func (j *Json) IP() (result net.IP, err error) {
+	text, err := j.String()
+	if err != nil {
+		return
+	}
+
+	result = net.ParseIP(text)
+	if result == nil {
+		err = errors.New("assert to ip failed")
+	}
+
+	return
+}
+
+// CIDR returns as *net.IPNet from json object, such as "192.0.2.0/24"
+func (j *Json) CIDR() (result *net.IPNet, err error) {
+	text, err := j.String()
+	if err != nil {
+		return
+	}
+
+	_, result, err = net.ParseCIDR(text)
+
+	return
+}
+
+// UUID returns as [16]byte from json object
+// the value must be in the form of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func (j *Json) UUID() (result [16]byte, err error) {
+	text, err := j.String()
+	if err != nil {
+		return
+	}
+
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return result, errors.New("assert to uuid failed")
+	}
+
+	text = text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	if _, err = hex.Decode(result[:], []byte(text)); err != nil {
+		return result, errors.New("assert to uuid failed")
+	}
+
+	return
+}
+
+// MustBytes returns as []byte from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustBytes(args ...[]byte) []byte {
+	if len(args) > 1 {
+		panic(j.pathError("MustBytes", ErrTooManyArguments))
+	}
+
+	r, err := j.Bytes()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustBytes", err))
+}
+
+// MustURL returns as *url.URL from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustURL(args ...*url.URL) *url.URL {
+	if len(args) > 1 {
+		panic(j.pathError("MustURL", ErrTooManyArguments))
+	}
+
+	r, err := j.URL()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustURL", err))
+}
+
+// MustIP returns as net.IP from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustIP(args ...net.IP) net.IP {
+	if len(args) > 1 {
+		panic(j.pathError("MustIP", ErrTooManyArguments))
+	}
+
+	r, err := j.IP()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustIP", err))
+}
+
+// MustCIDR returns as *net.IPNet from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustCIDR(args ...*net.IPNet) *net.IPNet {
+	if len(args) > 1 {
+		panic(j.pathError("MustCIDR", ErrTooManyArguments))
+	}
+
+	r, err := j.CIDR()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustCIDR", err))
+}
+
+// MustUUID returns as [16]byte from json object with optional default value
+// if error return default(if set) or panic
+func (j *Json) MustUUID(args ...[16]byte) [16]byte {
+	if len(args) > 1 {
+		panic(j.pathError("MustUUID", ErrTooManyArguments))
+	}
+
+	r, err := j.UUID()
+	if err == nil {
+		return r
+	}
+
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	panic(j.pathError("MustUUID", err))
+}
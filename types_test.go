@@ -0,0 +1,139 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Bytes(t *testing.T) {
+	// Bytes is dumped as base64 by encoding/json
+	jsonData := New()
+	jsonData.Set("bytes", []byte{0xfb, 0xff, 0x01})
+	text, err := jsonData.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, text, `{"bytes":"+/8B"}`)
+	assert.Equal(t, jsonData.Get("bytes").MustBytes(), []byte{0xfb, 0xff, 0x01})
+
+	// Loads and decode base64
+	jsonData, err = Loads(text)
+	assert.Nil(t, err)
+	assert.Equal(t, jsonData.Get("bytes").MustBytes(), []byte{0xfb, 0xff, 0x01})
+
+	// URL-safe and raw encoding
+	jsonData.Set("bytes", "-_8B")
+	assert.Equal(t, jsonData.Get("bytes").MustBytes(), []byte{0xfb, 0xff, 0x01})
+	jsonData.Set("bytes", "aGk")
+	assert.Equal(t, jsonData.Get("bytes").MustBytes(), []byte("hi"))
+	jsonData.Set("bytes", "aGk=")
+	assert.Equal(t, jsonData.Get("bytes").MustBytes(), []byte("hi"))
+
+	// Invalid base64
+	jsonData.Set("bytes", "!!!")
+	_, err = jsonData.Get("bytes").Bytes()
+	assert.NotNil(t, err)
+	assert.Equal(t, jsonData.Get("bytes").MustBytes([]byte("def")), []byte("def"))
+	assert.Panic(t, func() { jsonData.Get("bytes").MustBytes() })
+	assert.Panic(t, func() { jsonData.Get("bytes").MustBytes(nil, nil) })
+	_, err = jsonData.Get("not-exists").Bytes()
+	assert.NotNil(t, err)
+}
+
+func Test_URL(t *testing.T) {
+	jsonData := New()
+	jsonData.Set("url", "https://www.likexian.com/path?q=1")
+
+	u, err := jsonData.Get("url").URL()
+	assert.Nil(t, err)
+	assert.Equal(t, u.Host, "www.likexian.com")
+	assert.Equal(t, u.Path, "/path")
+	assert.Equal(t, u.Query().Get("q"), "1")
+
+	jsonData.Set("url", "http://[::1")
+	_, err = jsonData.Get("url").URL()
+	assert.NotNil(t, err)
+
+	def, _ := url.Parse("https://www.likexian.com/")
+	assert.Equal(t, jsonData.Get("url").MustURL(def), def)
+	assert.Equal(t, jsonData.Get("not-exists").MustURL(def), def)
+	assert.Panic(t, func() { jsonData.Get("url").MustURL() })
+	assert.Panic(t, func() { jsonData.Get("url").MustURL(def, def) })
+}
+
+func Test_IP_CIDR(t *testing.T) {
+	jsonData, err := Loads(`{"v4":"192.0.2.1","v6":"2001:db8::1","net":"192.0.2.0/24","bad":"300.0.0.1"}`)
+	assert.Nil(t, err)
+
+	ip, err := jsonData.Get("v4").IP()
+	assert.Nil(t, err)
+	assert.True(t, ip.Equal(net.IPv4(192, 0, 2, 1)))
+	assert.Equal(t, jsonData.Get("v6").MustIP().String(), "2001:db8::1")
+
+	_, err = jsonData.Get("bad").IP()
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("not-exists").IP()
+	assert.NotNil(t, err)
+	assert.Equal(t, jsonData.Get("bad").MustIP(net.IPv6loopback), net.IPv6loopback)
+	assert.Panic(t, func() { jsonData.Get("bad").MustIP() })
+	assert.Panic(t, func() { jsonData.Get("bad").MustIP(nil, nil) })
+
+	ipNet, err := jsonData.Get("net").CIDR()
+	assert.Nil(t, err)
+	assert.Equal(t, ipNet.String(), "192.0.2.0/24")
+	assert.True(t, ipNet.Contains(ip))
+
+	_, err = jsonData.Get("v4").CIDR()
+	assert.NotNil(t, err)
+	_, err = jsonData.Get("not-exists").CIDR()
+	assert.NotNil(t, err)
+	assert.Equal(t, jsonData.Get("v4").MustCIDR(ipNet), ipNet)
+	assert.Equal(t, jsonData.Get("net").MustCIDR().String(), "192.0.2.0/24")
+	assert.Panic(t, func() { jsonData.Get("v4").MustCIDR() })
+	assert.Panic(t, func() { jsonData.Get("v4").MustCIDR(nil, nil) })
+}
+
+func Test_UUID(t *testing.T) {
+	jsonData := New()
+	expect := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	jsonData.Set("id", "123e4567-e89b-12d3-a456-426614174000")
+	id, err := jsonData.Get("id").UUID()
+	assert.Nil(t, err)
+	assert.Equal(t, id, expect)
+
+	jsonData.Set("id", "123E4567-E89B-12D3-A456-426614174000")
+	assert.Equal(t, jsonData.Get("id").MustUUID(), expect)
+
+	for _, v := range []string{"", "123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400z", "123e4567+e89b-12d3-a456-426614174000"} {
+		jsonData.Set("id", v)
+		_, err = jsonData.Get("id").UUID()
+		assert.NotNil(t, err)
+	}
+
+	_, err = jsonData.Get("not-exists").UUID()
+	assert.NotNil(t, err)
+	assert.Equal(t, jsonData.Get("id").MustUUID(expect), expect)
+	assert.Panic(t, func() { jsonData.Get("id").MustUUID() })
+	assert.Panic(t, func() { jsonData.Get("id").MustUUID(expect, expect) })
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// InvalidUTF8 is the policy of invalid UTF-8 and lone surrogate escapes in strings for loading
+type InvalidUTF8 int
+
+// InvalidUTF8 values, if not set they are replaced with U+FFFD as encoding/json
+const (
+	// InvalidUTF8Replace replaces them with U+FFFD
+	InvalidUTF8Replace InvalidUTF8 = iota + 1
+	// InvalidUTF8Error returns *SyntaxError at the position of them
+	InvalidUTF8Error
+	// InvalidUTF8Preserve keeps the raw bytes, and lone surrogates as WTF-8 bytes
+	InvalidUTF8Preserve
+)
+
+// SetInvalidUTF8 set the policy of invalid UTF-8 and lone surrogates for loading, it is not set by default
+// the preserved bytes are replaced with U+FFFD by dumping, as encoding/json
+//   json := New()
+//   json.SetInvalidUTF8(InvalidUTF8Error)
+//   json.Loads(`["\ud83d"]`) // simplejson: 1:3: invalid lone surrogate \ud83d in string literal
+func (j *Json) SetInvalidUTF8(policy InvalidUTF8) {
+	j.invalidUTF8 = policy
+}
+
+// SetAsciiEscape set ascii escape for escaping all non-ASCII characters as \uXXXX by dumping,
+// characters beyond the Basic Multilingual Plane are escaped as surrogate pairs,
+// the edited source in preserve mode is not escaped
+func (j *Json) SetAsciiEscape(escape bool) {
+	j.asciiEscape = escape
+}
+
+// surrogate returns the lone surrogate r of \uXXXX at pos by the invalid UTF-8 policy,
+// with the length of escape
+func (p *parser) surrogate(r rune) (rune, int, error) {
+	switch p.invalid {
+	case InvalidUTF8Error:
+		return 0, 0, p.errorf("invalid lone surrogate \\u%04x in string literal", r)
+	case InvalidUTF8Preserve:
+		return r, 6, nil
+	default:
+		return utf8.RuneError, 6, nil
+	}
+}
+
+// appendRune appends the UTF-8 of r to buf, surrogate is appended as WTF-8
+func appendRune(buf []byte, r rune) []byte {
+	if utf16.IsSurrogate(r) {
+		return append(buf, byte(0xE0|r>>12), byte(0x80|r>>6&0x3F), byte(0x80|r&0x3F))
+	}
+
+	return append(buf, string(r)...)
+}
+
+// escapeASCII returns data with all non-ASCII characters escaped as \uXXXX
+func escapeASCII(data []byte) []byte {
+	var buf bytes.Buffer
+
+	for i := 0; i < len(data); {
+		if data[i] < utf8.RuneSelf {
+			buf.WriteByte(data[i])
+			i++
+			continue
+		}
+
+		r, n := utf8.DecodeRune(data[i:])
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			writeEscape(&buf, r1)
+			writeEscape(&buf, r2)
+		} else {
+			writeEscape(&buf, r)
+		}
+		i += n
+	}
+
+	return buf.Bytes()
+}
+
+// writeEscape writes the \uXXXX escape of r to buf
+func writeEscape(buf *bytes.Buffer, r rune) {
+	s := strconv.FormatInt(int64(r), 16)
+	buf.WriteString(`\u`)
+	for i := len(s); i < 4; i++ {
+		buf.WriteByte('0')
+	}
+	buf.WriteString(s)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func Test_Invalid_UTF8(t *testing.T) {
+	tests := []struct {
+		text     string
+		replace  string
+		preserve string
+		err      string
+	}{
+		{`"中文\u4e2d😀"`, "中文中😀", "中文中😀", ""},
+		{`"\ud83d\ude00"`, "😀", "😀", ""},
+		{"\"a\xffb\"", "a\ufffdb", "a\xffb", "1:3: invalid UTF-8 byte '\\xff' in string literal"},
+		{"\"\xe4\xb8\"", "\ufffd\ufffd", "\xe4\xb8", "1:2: invalid UTF-8 byte '\\xe4' in string literal"},
+		{`"a\ud83d"`, "a\ufffd", "a\xed\xa0\xbd", `1:3: invalid lone surrogate \ud83d in string literal`},
+		{`"\ude00x"`, "\ufffdx", "\xed\xb8\x80x", `1:2: invalid lone surrogate \ude00 in string literal`},
+		{`"\ud83d\u0041"`, "\ufffdA", "\xed\xa0\xbdA", `1:2: invalid lone surrogate \ud83d in string literal`},
+		{"{\"\xff\": 1}", "\ufffd", "\xff", "1:3: invalid UTF-8 byte '\\xff' in string literal"},
+	}
+
+	for _, v := range tests {
+		j, err := Loads(v.text)
+		assert.Nil(t, err, v.text)
+		std := j.Keys()
+		if j.IsMap() {
+			assert.Equal(t, std, []string{v.replace}, v.text)
+		} else {
+			assert.Equal(t, j.MustString(), v.replace, v.text)
+		}
+
+		j = New()
+		j.SetInvalidUTF8(InvalidUTF8Replace)
+		err = j.Loads(v.text)
+		assert.Nil(t, err, v.text)
+		if j.IsMap() {
+			assert.Equal(t, j.Keys(), []string{v.replace}, v.text)
+		} else {
+			assert.Equal(t, j.MustString(), v.replace, v.text)
+		}
+
+		j.SetInvalidUTF8(InvalidUTF8Preserve)
+		err = j.Loads(v.text)
+		assert.Nil(t, err, v.text)
+		if j.IsMap() {
+			assert.Equal(t, j.Keys(), []string{v.preserve}, v.text)
+		} else {
+			assert.Equal(t, j.MustString(), v.preserve, v.text)
+		}
+
+		j.SetInvalidUTF8(InvalidUTF8Error)
+		err = j.Loads(v.text)
+		if v.err == "" {
+			assert.Nil(t, err, v.text)
+		} else {
+			assert.NotNil(t, err, v.text)
+			assert.Equal(t, err.Error(), "simplejson: "+v.err, v.text)
+			_, ok := err.(*SyntaxError)
+			assert.True(t, ok, v.text)
+		}
+	}
+
+	j := New()
+	j.SetInvalidUTF8(InvalidUTF8Preserve)
+	err := j.Loads("\"a\xffb\"")
+	assert.Nil(t, err)
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "\"a\ufffdb\"")
+}
+
+func Test_Ascii_Escape(t *testing.T) {
+	j := New(map[string]interface{}{"中文": "Li Kexian 中文 😀 é <", "a": []interface{}{"\u2028"}})
+	j.SetAsciiEscape(true)
+
+	s, err := j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":["\u2028"],"\u4e2d\u6587":"Li Kexian \u4e2d\u6587 \ud83d\ude00 \u00e9 <"}`)
+
+	s, err = j.PrettyDumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, "{\n    \"a\": [\n        \"\\u2028\"\n    ],\n"+
+		"    \"\\u4e2d\\u6587\": \"Li Kexian \\u4e2d\\u6587 \\ud83d\\ude00 \\u00e9 <\"\n}")
+
+	s, err = j.DumpsJSON5()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{a:["\u2028"],\u4e2d\u6587:"Li Kexian \u4e2d\u6587 \ud83d\ude00 \u00e9 <"}`)
+
+	r, err := Loads(`{"a":["\u2028"],"\u4e2d\u6587":"Li Kexian \u4e2d\u6587 \ud83d\ude00 \u00e9 <"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, r.Get("中文").MustString(), "Li Kexian 中文 😀 é <")
+
+	r, err = LoadsJSON5(s)
+	assert.Nil(t, err)
+	assert.Equal(t, r.Get("中文").MustString(), "Li Kexian 中文 😀 é <")
+
+	j.SetAsciiEscape(false)
+	s, err = j.Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `{"a":["\u2028"],"中文":"Li Kexian 中文 😀 é <"}`)
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// StreamWriter writes json token by token to io.Writer, without building the whole json
+// errors are sticky, once an error occurred, all the later calls return the error
+//   w := NewStreamWriter(writer)
+//   w.BeginObject()
+//   w.Key("items")
+//   w.BeginArray()
+//   for _, v := range items {
+//       w.Value(v)
+//   }
+//   w.End()
+//   w.End()
+//   err := w.Close()
+type StreamWriter struct {
+	writer     *bufio.Writer
+	escapeHtml bool
+	indent     string
+	levels     []*streamLevel
+	done       bool
+	err        error
+}
+
+// streamLevel is an open object or array of StreamWriter
+type streamLevel struct {
+	object bool
+	count  int
+	keyed  bool
+}
+
+// NewStreamWriter returns a new StreamWriter writing to w
+func NewStreamWriter(w io.Writer) *StreamWriter {
+	return &StreamWriter{
+		writer: bufio.NewWriter(w),
+	}
+}
+
+// SetHtmlEscape set html escape for escaping of <, >, and & in JSON strings
+func (w *StreamWriter) SetHtmlEscape(escape bool) {
+	w.escapeHtml = escape
+}
+
+// SetPretty set pretty output with identation as PrettyDumps
+func (w *StreamWriter) SetPretty(pretty bool) {
+	if pretty {
+		w.indent = strings.Repeat(" ", 4)
+	} else {
+		w.indent = ""
+	}
+}
+
+// BeginObject begins writing an object
+func (w *StreamWriter) BeginObject() error {
+	return w.begin(true)
+}
+
+// BeginArray begins writing an array
+func (w *StreamWriter) BeginArray() error {
+	return w.begin(false)
+}
+
+// Key writes the key of the next value in object
+func (w *StreamWriter) Key(key string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	level := w.level()
+	if level == nil || !level.object {
+		return w.fail(errors.New("key is not in object"))
+	}
+
+	if level.keyed {
+		return w.fail(errors.New("key is already written"))
+	}
+
+	result, err := w.encode(key, "")
+	if err != nil {
+		return w.fail(err)
+	}
+
+	w.separate()
+	w.writer.Write(result)
+	w.writer.WriteByte(':')
+	if w.indent != "" {
+		w.writer.WriteByte(' ')
+	}
+
+	level.keyed = true
+
+	return nil
+}
+
+// Value writes a value, value can be *Json or any data could be used by New
+func (w *StreamWriter) Value(value interface{}) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	if j, ok := value.(*Json); ok {
+		value = j.data
+	}
+
+	result, err := w.encode(value, strings.Repeat(w.indent, len(w.levels)))
+	if err != nil {
+		return w.fail(err)
+	}
+
+	w.element()
+	w.writer.Write(result)
+	w.complete()
+
+	return nil
+}
+
+// End ends writing the current object or array
+func (w *StreamWriter) End() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	level := w.level()
+	if level == nil {
+		return w.fail(errors.New("no object or array to end"))
+	}
+
+	if level.keyed {
+		return w.fail(errors.New("missing value of key"))
+	}
+
+	w.levels = w.levels[:len(w.levels)-1]
+	if w.indent != "" && level.count > 0 {
+		w.newline()
+	}
+
+	if level.object {
+		w.writer.WriteByte('}')
+	} else {
+		w.writer.WriteByte(']')
+	}
+
+	w.complete()
+
+	return nil
+}
+
+// Close checks all objects and arrays are ended, and flushes data to io.Writer
+// the io.Writer is not closed
+func (w *StreamWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if len(w.levels) > 0 {
+		return w.fail(errors.New("object or array is not ended"))
+	}
+
+	return w.fail(w.writer.Flush())
+}
+
+// begin begins writing an object or array
+func (w *StreamWriter) begin(object bool) error {
+	if err := w.check(); err != nil {
+		return err
+	}
+
+	w.element()
+	if object {
+		w.writer.WriteByte('{')
+	} else {
+		w.writer.WriteByte('[')
+	}
+
+	w.levels = append(w.levels, &streamLevel{object: object})
+
+	return nil
+}
+
+// check checks a value could be written
+func (w *StreamWriter) check() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	level := w.level()
+	if level == nil && w.done {
+		return w.fail(errors.New("top-level value is already written"))
+	}
+
+	if level != nil && level.object && !level.keyed {
+		return w.fail(errors.New("missing key of value"))
+	}
+
+	return nil
+}
+
+// element writes the separator before a value if it is not after a key
+func (w *StreamWriter) element() {
+	level := w.level()
+	if level != nil && !level.object {
+		w.separate()
+	}
+}
+
+// complete marks a value is written
+func (w *StreamWriter) complete() {
+	level := w.level()
+	if level == nil {
+		w.done = true
+		return
+	}
+
+	level.count++
+	level.keyed = false
+}
+
+// separate writes the separator before an element of current level
+func (w *StreamWriter) separate() {
+	if w.level().count > 0 {
+		w.writer.WriteByte(',')
+	}
+
+	if w.indent != "" {
+		w.newline()
+	}
+}
+
+// newline writes a new line with identation of current level
+func (w *StreamWriter) newline() {
+	w.writer.WriteByte('\n')
+	w.writer.WriteString(strings.Repeat(w.indent, len(w.levels)))
+}
+
+// level returns the current level, nil if at the top level
+func (w *StreamWriter) level() *streamLevel {
+	if len(w.levels) == 0 {
+		return nil
+	}
+
+	return w.levels[len(w.levels)-1]
+}
+
+// encode returns value encoded with html escape and identation setting
+func (w *StreamWriter) encode(value interface{}, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(w.escapeHtml)
+	enc.SetIndent(prefix, w.indent)
+	err := enc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// fail records the error as sticky error
+func (w *StreamWriter) fail(err error) error {
+	if w.err == nil {
+		w.err = err
+	}
+
+	return err
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2012-2019 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Go module for JSON parsing
+ * https://www.likexian.com/
+ */
+
+package simplejson
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/likexian/gokit/assert"
+)
+
+func writeStream(w *StreamWriter) error {
+	w.BeginObject()
+	w.Key("title")
+	w.Value("<report>")
+	w.Key("items")
+	w.BeginArray()
+	w.Value(map[string]interface{}{"id": 1, "tags": []string{"a"}})
+	w.Value(New([]interface{}{2}))
+	w.BeginObject()
+	w.End()
+	w.BeginArray()
+	w.End()
+	w.End()
+	w.Key("empty")
+	w.BeginArray()
+	w.End()
+	w.End()
+	return w.Close()
+}
+
+func Test_Stream_Writer(t *testing.T) {
+	var buf bytes.Buffer
+
+	// Compact output is the same as Dumps
+	err := writeStream(NewStreamWriter(&buf))
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), `{"title":"<report>","items":[{"id":1,"tags":["a"]},[2],{},[]],"empty":[]}`)
+
+	j, err := Loads(buf.String())
+	assert.Nil(t, err)
+	s, err := j.Get("items").Dumps()
+	assert.Nil(t, err)
+	assert.Equal(t, s, `[{"id":1,"tags":["a"]},[2],{},[]]`)
+
+	// Pretty output is the same as PrettyDumps
+	buf.Reset()
+	w := NewStreamWriter(&buf)
+	w.SetPretty(true)
+	w.SetHtmlEscape(true)
+	err = writeStream(w)
+	assert.Nil(t, err)
+
+	expect := `{
+    "title": "\u003creport\u003e",
+    "items": [
+        {
+            "id": 1,
+            "tags": [
+                "a"
+            ]
+        },
+        [
+            2
+        ],
+        {},
+        []
+    ],
+    "empty": []
+}`
+	assert.Equal(t, buf.String(), expect)
+
+	// Pretty value is the same as PrettyDumps
+	s, err = New(map[string]interface{}{"id": 1, "tags": []string{"a"}}).PrettyDumps()
+	assert.Nil(t, err)
+	assert.Contains(t, expect, strings.Replace(s, "\n", "\n        ", -1))
+
+	// Top level value
+	buf.Reset()
+	w = NewStreamWriter(&buf)
+	w.SetPretty(true)
+	w.SetPretty(false)
+	assert.Nil(t, w.Value(1))
+	assert.NotNil(t, w.Value(2))
+	assert.NotNil(t, w.Close())
+	assert.Equal(t, buf.String(), ``)
+
+	buf.Reset()
+	w = NewStreamWriter(&buf)
+	assert.Nil(t, w.Value(1))
+	assert.Nil(t, w.Close())
+	assert.Equal(t, buf.String(), `1`)
+}
+
+func Test_Stream_Writer_Error(t *testing.T) {
+	var buf bytes.Buffer
+
+	cases := []func(w *StreamWriter) error{
+		func(w *StreamWriter) error { return w.Key("a") },
+		func(w *StreamWriter) error { return w.End() },
+		func(w *StreamWriter) error { w.BeginArray(); return w.Key("a") },
+		func(w *StreamWriter) error { w.BeginObject(); return w.Value(1) },
+		func(w *StreamWriter) error { w.BeginObject(); return w.BeginArray() },
+		func(w *StreamWriter) error { w.BeginObject(); w.Key("a"); return w.Key("b") },
+		func(w *StreamWriter) error { w.BeginObject(); w.Key("a"); return w.End() },
+		func(w *StreamWriter) error { w.BeginObject(); return w.Close() },
+		func(w *StreamWriter) error { w.BeginArray(); return w.Value(make(chan int)) },
+		func(w *StreamWriter) error { w.BeginObject(); w.End(); return w.BeginObject() },
+		func(w *StreamWriter) error { w.Value(1); return w.Close() },
+	}
+
+	for i, fn := range cases {
+		w := NewStreamWriter(&buf)
+		if i == len(cases)-1 {
+			w = NewStreamWriter(errorWriter{})
+		}
+		err := fn(w)
+		assert.NotNil(t, err, i)
+		// error is sticky
+		assert.Equal(t, w.BeginObject(), err)
+		assert.Equal(t, w.Key("a"), err)
+		assert.Equal(t, w.Value(1), err)
+		assert.Equal(t, w.End(), err)
+		assert.Equal(t, w.Close(), err)
+	}
+}